@@ -2,22 +2,84 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
+	"sync"
 
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/shared"
 	"github.com/megaport/megaportgo/types"
 )
 
+// DefaultUserAgent is sent with API calls when the Config does not specify a user agent.
+const DefaultUserAgent = "Go-Megaport-Library/0.2.2"
+
 type Config struct {
-	Log          Logger
-	Endpoint     string
+	Log      Logger
+	Endpoint string
+
+	// SessionToken is the bearer token sent with API calls. Once API calls may be in progress, change it with
+	// SetSessionToken rather than assigning it.
 	SessionToken string
 	Client       httpClientInterface
+	UserAgent    string
+
+	// Environment, if set, supplies the OAuth token URL for the API at Endpoint. Use SetEnvironment to set both.
+	Environment *Environment
+
+	// Retry is the policy for retrying failed API calls. Calls are not retried if it is nil.
+	Retry *RetryPolicy
+
+	// RateLimiter limits how quickly API calls are made by all services sharing the Config. Calls are not limited
+	// if it is nil.
+	RateLimiter *RateLimiter
+
+	// sessionMu guards SessionToken, which the authentication service changes while other goroutines make API calls.
+	sessionMu sync.RWMutex
+}
+
+// SetSessionToken sets the bearer token sent with API calls. It is safe to call while API calls are being made.
+func (c *Config) SetSessionToken(token string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	c.SessionToken = token
+}
+
+// GetSessionToken returns the bearer token sent with API calls.
+func (c *Config) GetSessionToken() string {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+
+	return c.SessionToken
+}
+
+// SetEnvironment points the Config at the API of the given environment.
+func (c *Config) SetEnvironment(env Environment) error {
+	if err := env.Validate(); err != nil {
+		return err
+	}
+
+	c.Environment = &env
+	c.Endpoint = env.APIEndpoint
+	return nil
+}
+
+// TokenURL returns the OAuth token URL to use with the Config's API. It is taken from the Config's Environment if
+// set, otherwise from the Megaport environment at Endpoint.
+func (c *Config) TokenURL() (string, error) {
+	if c.Environment != nil {
+		return c.Environment.TokenURL, nil
+	}
+
+	env, err := EnvironmentForEndpoint(c.Endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	return env.TokenURL, nil
 }
 
 // http interface to cover mocking
@@ -31,7 +93,9 @@ type roundTripper struct {
 }
 
 func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Add("User-Agent", "Go-Megaport-Library/0.2.2")
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", DefaultUserAgent)
+	}
 
 	return t.T.RoundTrip(req)
 }
@@ -40,19 +104,66 @@ func NewHttpClient() *http.Client {
 	return &http.Client{Transport: &roundTripper{http.DefaultTransport}}
 }
 
-// MakeAPICall
+// MakeAPICall makes a call to the Megaport API. It is the same as MakeAPICallWithContext using the background
+// context.
 func (c *Config) MakeAPICall(verb string, endpoint string, body []byte) (*http.Response, error) {
-	var request *http.Request
-	var reqErr error
+	return c.MakeAPICallWithContext(context.Background(), verb, endpoint, body)
+}
 
+// MakeAPICallWithContext makes a call to the Megaport API, cancelling the request if the context is cancelled or its
+// deadline expires. Calls wait for the Config's rate limiter, and failed calls are retried according to its retry
+// policy.
+func (c *Config) MakeAPICallWithContext(ctx context.Context, verb string, endpoint string, body []byte) (*http.Response, error) {
 	url := c.Endpoint + endpoint
 
-	c.Log.Debugln("Making call to: ", string(url))
+	// check config for http client, create a new client if one was not provided at instantiation
+	if c.Client == nil {
+		c.Client = NewHttpClient()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		c.Log.Debugln("Making call to: ", string(url))
+
+		response, resErr := c.doAPICall(ctx, verb, url, body)
+
+		if ctx.Err() != nil || !c.Retry.shouldRetry(verb, attempt, response, resErr) {
+			return response, resErr
+		}
+
+		delay, ok := c.Retry.delay(attempt, response)
+		if !ok {
+			return response, resErr
+		}
+
+		if response != nil {
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+			c.Log.Debugf("%s %s returned %d, retrying in %s", verb, endpoint, response.StatusCode, delay)
+		} else {
+			c.Log.Debugf("%s %s failed: %v, retrying in %s", verb, endpoint, resErr, delay)
+		}
+
+		if err := shared.SleepWithContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doAPICall makes a single attempt at an API call.
+func (c *Config) doAPICall(ctx context.Context, verb string, url string, body []byte) (*http.Response, error) {
+	var request *http.Request
+	var reqErr error
 
 	if body == nil {
-		request, reqErr = http.NewRequest(verb, url, nil)
+		request, reqErr = http.NewRequestWithContext(ctx, verb, url, nil)
 	} else {
-		request, reqErr = http.NewRequest(verb, url, bytes.NewBuffer(body))
+		request, reqErr = http.NewRequestWithContext(ctx, verb, url, bytes.NewBuffer(body))
 	}
 
 	if reqErr != nil {
@@ -60,49 +171,65 @@ func (c *Config) MakeAPICall(verb string, endpoint string, body []byte) (*http.R
 	}
 
 	// Set the bearer token in the request header
-	request.Header.Set("Authorization", "Bearer "+c.SessionToken)
+	request.Header.Set("Authorization", "Bearer "+c.GetSessionToken())
 
 	if body != nil {
 		request.Header.Set("Content-Type", "application/json")
 	}
 
-	// check config for http client, create a new client if one was not provided at instantiation
-	if c.Client == nil {
-		c.Client = NewHttpClient()
-
+	if c.UserAgent != "" {
+		request.Header.Set("User-Agent", c.UserAgent)
 	}
 
-	response, resErr := c.Client.Do(request)
-
-	if resErr != nil {
-		return nil, resErr
-	} else {
-		return response, nil
-	}
+	return c.Client.Do(request)
 }
 
-// IsErrorResponse returns an error report if an error response is detected from the API.
+// RequestIDHeaders are the response headers checked, in order, for an identifier of the failed request when
+// building an APIError.
+var RequestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Correlation-Id"}
+
+// IsErrorResponse returns an error report if an error response is detected from the API. Error responses are
+// returned as a *mega_err.APIError.
 func (c *Config) IsErrorResponse(response *http.Response, responseErr *error, expectedReturnCode int) (bool, error) {
 	if *responseErr != nil {
 		return true, *responseErr
 	}
 
 	if response.StatusCode != expectedReturnCode {
-		body, fileErr := ioutil.ReadAll(response.Body)
+		body, fileErr := io.ReadAll(response.Body)
 
 		if fileErr != nil {
 			return false, fileErr
 		}
 
+		apiErr := &mega_err.APIError{StatusCode: response.StatusCode}
+
+		if response.Request != nil {
+			apiErr.Method = response.Request.Method
+			apiErr.Path = response.Request.URL.Path
+		}
+
+		for _, header := range RequestIDHeaders {
+			if requestID := response.Header.Get(header); requestID != "" {
+				apiErr.RequestID = requestID
+				break
+			}
+		}
+
 		errResponse := types.ErrorResponse{}
 		errParseErr := json.Unmarshal([]byte(body), &errResponse)
 
 		if errParseErr != nil {
-			errorReport := fmt.Sprintf(mega_err.ERR_PARSING_ERR_RESPONSE, response.StatusCode, errParseErr.Error(), string(body))
-			return true, errors.New(errorReport)
+			apiErr.Body = string(body)
+			apiErr.Err = errParseErr
+			return true, apiErr
 		}
 
-		return true, errors.New(errResponse.Message + ": " + errResponse.Data)
+		apiErr.Message = errResponse.Message
+		apiErr.Terms = errResponse.Terms
+		apiErr.Data = errResponse.Data
+
+		return true, apiErr
 	}
 
 	return false, nil
@@ -120,18 +247,21 @@ func (c *Config) PurchaseError(productID string, err error) {
 }
 
 func (c *Config) GetProductType(productId string) (string, error) {
+	return c.GetProductTypeWithContext(context.Background(), productId)
+}
+
+func (c *Config) GetProductTypeWithContext(ctx context.Context, productId string) (string, error) {
 	url := "/v2/product/" + productId
 	verb := "GET"
 
-	detailsResponse, err := c.MakeAPICall(verb, url, nil)
-	defer detailsResponse.Body.Close()
-
+	detailsResponse, err := c.MakeAPICallWithContext(ctx, verb, url, nil)
 	isResErr, compiledResErr := c.IsErrorResponse(detailsResponse, &err, 200)
 	if isResErr {
 		return "", compiledResErr
 	}
+	defer detailsResponse.Body.Close()
 
-	body, fileErr := ioutil.ReadAll(detailsResponse.Body)
+	body, fileErr := io.ReadAll(detailsResponse.Body)
 
 	if fileErr != nil {
 		return "", fileErr
@@ -139,8 +269,18 @@ func (c *Config) GetProductType(productId string) (string, error) {
 
 	obj := map[string]interface{}{}
 	if err := json.Unmarshal([]byte(body), &obj); err != nil {
-		c.Log.Error(err)
+		return "", err
+	}
+
+	data, ok := obj["data"].(map[string]interface{})
+	if !ok {
+		return "", mega_err.ErrMissingProductType
+	}
+
+	productType, ok := data["productType"].(string)
+	if !ok {
+		return "", mega_err.ErrMissingProductType
 	}
 
-	return obj["data"].(map[string]interface{})["productType"].(string), nil
+	return productType, nil
 }
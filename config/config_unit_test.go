@@ -0,0 +1,379 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConfig(server *httptest.Server) *Config {
+	logger := NewDefaultLogger()
+	logger.SetLevel(Off)
+
+	return &Config{
+		Log:          logger,
+		Endpoint:     server.URL,
+		SessionToken: "test-token",
+		Client:       server.Client(),
+	}
+}
+
+func TestMakeAPICallWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	response, err := cfg.MakeAPICallWithContext(context.Background(), "GET", "/v2/products", nil)
+
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestMakeAPICallWithContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cfg.MakeAPICallWithContext(ctx, "GET", "/v2/products", nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestIsErrorResponseAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1234")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Could not find a service with UID","terms":"terms","data":"bad-uid"}`))
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	response, err := cfg.MakeAPICall("GET", "/v2/product/bad-uid", nil)
+	isErr, parsedErr := cfg.IsErrorResponse(response, &err, 200)
+
+	assert.True(t, isErr)
+	assert.EqualError(t, parsedErr, "Could not find a service with UID: bad-uid")
+	assert.True(t, mega_err.IsNotFound(parsedErr))
+	assert.False(t, mega_err.IsUnauthorized(parsedErr))
+	assert.False(t, mega_err.IsRetryable(parsedErr))
+
+	apiErr, ok := mega_err.AsAPIError(parsedErr)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, "/v2/product/bad-uid", apiErr.Path)
+	assert.Equal(t, "req-1234", apiErr.RequestID)
+	assert.Equal(t, "terms", apiErr.Terms)
+}
+
+func TestIsErrorResponseUnparseable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html>Bad Gateway</html>`))
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	response, err := cfg.MakeAPICall("GET", "/v2/products", nil)
+	isErr, parsedErr := cfg.IsErrorResponse(response, &err, 200)
+
+	assert.True(t, isErr)
+	assert.ErrorIs(t, parsedErr, mega_err.ErrServerError)
+	assert.True(t, mega_err.IsRetryable(parsedErr))
+	assert.Contains(t, parsedErr.Error(), "<html>Bad Gateway</html>")
+}
+
+func TestGetProductType(t *testing.T) {
+	responses := map[string]string{
+		"/v2/product/port-1":   `{"message":"Found","data":{"productUid":"port-1","productType":"MEGAPORT"}}`,
+		"/v2/product/no-data":  `{"message":"Found"}`,
+		"/v2/product/no-type":  `{"message":"Found","data":{"productUid":"no-type"}}`,
+		"/v2/product/bad-type": `{"message":"Found","data":{"productUid":"bad-type","productType":3}}`,
+		"/v2/product/bad-json": `{"message":`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/product/unknown-uid" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Could not find a service with UID unknown-uid","data":null}`))
+			return
+		}
+		w.Write([]byte(responses[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+
+	productType, err := cfg.GetProductType("port-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "MEGAPORT", productType)
+
+	_, err = cfg.GetProductType("unknown-uid")
+	assert.True(t, mega_err.IsNotFound(err))
+
+	for _, uid := range []string{"no-data", "no-type", "bad-type"} {
+		_, err = cfg.GetProductType(uid)
+		assert.ErrorIs(t, err, mega_err.ErrMissingProductType, uid)
+	}
+
+	_, err = cfg.GetProductType("bad-json")
+	assert.Error(t, err)
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	cfg.Retry = testRetryPolicy()
+
+	response, err := cfg.MakeAPICall("GET", "/v2/products", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	cfg.Retry = testRetryPolicy()
+
+	response, err := cfg.MakeAPICall("GET", "/v2/products", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, cfg.Retry.MaxAttempts, attempts)
+}
+
+func TestRetryOrderOnlyWhenSafe(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	cfg.Retry = testRetryPolicy()
+
+	response, err := cfg.MakeAPICall("POST", "/v3/networkdesign/buy", []byte(`[]`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	cfg.Retry = testRetryPolicy()
+
+	response, err := cfg.MakeAPICall("POST", "/v3/networkdesign/buy", []byte(`[]`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	cfg.Retry = testRetryPolicy()
+
+	response, err := cfg.MakeAPICall("GET", "/v2/products", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(100, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+
+	// Two calls use the burst, the other two wait ~10ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	stats := limiter.Stats()
+	assert.Equal(t, int64(4), stats.Requests)
+	assert.Equal(t, int64(2), stats.Delayed)
+	assert.Greater(t, stats.TotalWait, time.Duration(0))
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+	assert.Equal(t, int64(1), limiter.Stats().Requests)
+}
+
+func TestMakeAPICallRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(server)
+	cfg.RateLimiter = NewRateLimiter(1000, 1)
+
+	for i := 0; i < 3; i++ {
+		response, err := cfg.MakeAPICall("GET", "/v2/products", nil)
+		if assert.NoError(t, err) {
+			response.Body.Close()
+		}
+	}
+
+	assert.Equal(t, int64(3), cfg.RateLimiter.Stats().Requests)
+}
+
+func TestEnvironmentForEndpoint(t *testing.T) {
+	env, err := EnvironmentForEndpoint("https://API-STAGING.megaport.com")
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentStaging, env)
+
+	_, err = EnvironmentForEndpoint("https://api.example.com/")
+	assert.ErrorIs(t, err, mega_err.ErrUnknownEnvironment)
+}
+
+func TestTokenURL(t *testing.T) {
+	cfg := &Config{Endpoint: "https://api-uat2.megaport.com/"}
+	tokenURL, err := cfg.TokenURL()
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentUAT2.TokenURL, tokenURL)
+
+	cfg.Endpoint = "http://localhost:8080"
+	_, err = cfg.TokenURL()
+	assert.ErrorIs(t, err, mega_err.ErrUnknownEnvironment)
+
+	assert.NoError(t, cfg.SetEnvironment(CustomEnvironment("http://localhost:8080", "http://localhost:8080/oauth2/token")))
+	tokenURL, err = cfg.TokenURL()
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/oauth2/token", tokenURL)
+}
+
+func TestEnvironmentValidate(t *testing.T) {
+	assert.NoError(t, EnvironmentProduction.Validate())
+	assert.ErrorIs(t, CustomEnvironment("localhost:8080", "http://localhost/token").Validate(), mega_err.ErrInvalidEnvironment)
+	assert.ErrorIs(t, CustomEnvironment("http://localhost:8080", "").Validate(), mega_err.ErrInvalidEnvironment)
+}
+
+func TestEnvironmentFromEnv(t *testing.T) {
+	env, err := EnvironmentFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentProduction, env)
+
+	t.Setenv(ENV_ENVIRONMENT, "UAT")
+	env, err = EnvironmentFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentUAT, env)
+
+	t.Setenv(ENV_ENVIRONMENT, "")
+	t.Setenv(ENV_API_ENDPOINT, "http://localhost:8080")
+	t.Setenv(ENV_TOKEN_URL, "http://localhost:8080/oauth2/token")
+	env, err = EnvironmentFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, ENVIRONMENT_CUSTOM, env.Name)
+
+	t.Setenv(ENV_ENVIRONMENT, "staging")
+	_, err = EnvironmentFromEnv()
+	assert.ErrorIs(t, err, mega_err.ErrInvalidEnvironment)
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/megaport/megaportgo/mega_err"
+)
+
+const (
+	ENVIRONMENT_PRODUCTION = "production"
+	ENVIRONMENT_STAGING    = "staging"
+	ENVIRONMENT_UAT        = "uat"
+	ENVIRONMENT_UAT2       = "uat2"
+	ENVIRONMENT_CUSTOM     = "custom"
+)
+
+// Environment variables read by EnvironmentFromEnv.
+const (
+	ENV_ENVIRONMENT  = "MEGAPORT_ENVIRONMENT"
+	ENV_API_ENDPOINT = "MEGAPORT_API_ENDPOINT"
+	ENV_TOKEN_URL    = "MEGAPORT_TOKEN_URL"
+)
+
+// Environment is a Megaport API deployment: the base URL of its API and the URL of its OAuth token endpoint.
+type Environment struct {
+	Name        string
+	APIEndpoint string
+	TokenURL    string
+}
+
+var (
+	EnvironmentProduction = Environment{
+		Name:        ENVIRONMENT_PRODUCTION,
+		APIEndpoint: "https://api.megaport.com/",
+		TokenURL:    "https://auth-m2m.megaport.com/oauth2/token",
+	}
+	EnvironmentStaging = Environment{
+		Name:        ENVIRONMENT_STAGING,
+		APIEndpoint: "https://api-staging.megaport.com/",
+		TokenURL:    "https://oauth-m2m-staging.auth.ap-southeast-2.amazoncognito.com/oauth2/token",
+	}
+	EnvironmentUAT = Environment{
+		Name:        ENVIRONMENT_UAT,
+		APIEndpoint: "https://api-uat.megaport.com/",
+		TokenURL:    "https://oauth-m2m-uat.auth.ap-southeast-2.amazoncognito.com/oauth2/token",
+	}
+	EnvironmentUAT2 = Environment{
+		Name:        ENVIRONMENT_UAT2,
+		APIEndpoint: "https://api-uat2.megaport.com/",
+		TokenURL:    "https://oauth-m2m-uat2.auth.ap-southeast-2.amazoncognito.com/oauth2/token",
+	}
+)
+
+var knownEnvironments = []Environment{EnvironmentProduction, EnvironmentStaging, EnvironmentUAT, EnvironmentUAT2}
+
+// CustomEnvironment returns an Environment for an API that is not one of Megaport's, such as a local stand-in or a
+// proxy.
+func CustomEnvironment(apiEndpoint, tokenURL string) Environment {
+	return Environment{
+		Name:        ENVIRONMENT_CUSTOM,
+		APIEndpoint: apiEndpoint,
+		TokenURL:    tokenURL,
+	}
+}
+
+// Validate checks that the API endpoint and token URL are absolute HTTP(S) URLs.
+func (e Environment) Validate() error {
+	if err := validateEnvironmentURL(e.APIEndpoint); err != nil {
+		return fmt.Errorf("%w: API endpoint %s", mega_err.ErrInvalidEnvironment, err)
+	}
+
+	if err := validateEnvironmentURL(e.TokenURL); err != nil {
+		return fmt.Errorf("%w: token URL %s", mega_err.ErrInvalidEnvironment, err)
+	}
+
+	return nil
+}
+
+func validateEnvironmentURL(rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("is not set")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL", rawURL)
+	}
+
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute http or https URL", rawURL)
+	}
+
+	return nil
+}
+
+// EnvironmentByName returns the Megaport environment with the given name, e.g. "production" or "staging".
+func EnvironmentByName(name string) (Environment, error) {
+	for _, env := range knownEnvironments {
+		if strings.EqualFold(env.Name, name) {
+			return env, nil
+		}
+	}
+
+	return Environment{}, fmt.Errorf("%w: %q", mega_err.ErrUnknownEnvironment, name)
+}
+
+// EnvironmentForEndpoint returns the Megaport environment whose API is at endpoint. Trailing slashes and the case of
+// the host are ignored.
+func EnvironmentForEndpoint(endpoint string) (Environment, error) {
+	for _, env := range knownEnvironments {
+		if normaliseEndpoint(env.APIEndpoint) == normaliseEndpoint(endpoint) {
+			return env, nil
+		}
+	}
+
+	return Environment{}, fmt.Errorf("%w: %q", mega_err.ErrUnknownEnvironment, endpoint)
+}
+
+func normaliseEndpoint(endpoint string) string {
+	return strings.ToLower(strings.TrimRight(endpoint, "/"))
+}
+
+// EnvironmentFromEnv selects an environment using environment variables. MEGAPORT_ENVIRONMENT names one of the
+// Megaport environments; alternatively MEGAPORT_API_ENDPOINT and MEGAPORT_TOKEN_URL define a custom one. Production
+// is returned if none of them are set.
+func EnvironmentFromEnv() (Environment, error) {
+	name := os.Getenv(ENV_ENVIRONMENT)
+	apiEndpoint := os.Getenv(ENV_API_ENDPOINT)
+	tokenURL := os.Getenv(ENV_TOKEN_URL)
+
+	if apiEndpoint != "" || tokenURL != "" {
+		if name != "" && !strings.EqualFold(name, ENVIRONMENT_CUSTOM) {
+			return Environment{}, fmt.Errorf("%w: %s cannot be combined with %s and %s", mega_err.ErrInvalidEnvironment,
+				ENV_ENVIRONMENT, ENV_API_ENDPOINT, ENV_TOKEN_URL)
+		}
+
+		env := CustomEnvironment(apiEndpoint, tokenURL)
+		return env, env.Validate()
+	}
+
+	if name != "" {
+		return EnvironmentByName(name)
+	}
+
+	return EnvironmentProduction, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/megaport/megaportgo/shared"
+)
+
+// RateLimiter is a token bucket limiting how quickly API calls are made. A single RateLimiter set on a Config is
+// shared by every service created from that Config. It is safe for concurrent use.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	stats RateLimiterStats
+}
+
+// RateLimiterStats reports how much the rate limiter has delayed API calls.
+type RateLimiterStats struct {
+	Requests  int64
+	Delayed   int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond calls on average, with bursts of up to burst calls.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a call may be made, or returns the context's error if it is cancelled first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+
+	if wait > 0 {
+		if err := shared.SleepWithContext(ctx, wait); err != nil {
+			l.cancel()
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	if wait > 0 {
+		l.stats.Delayed++
+		l.stats.TotalWait += wait
+		if wait > l.stats.MaxWait {
+			l.stats.MaxWait = wait
+		}
+	}
+
+	return nil
+}
+
+// Stats returns a snapshot of the rate limiter's statistics.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stats
+}
+
+// reserve takes a token from the bucket and returns how long the caller must wait before the token is available.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how MakeAPICall retries failed requests. Retries are disabled when Config.Retry is nil.
+//
+// Requests using one of the RetryableMethods are retried on transport errors and on any of the RetryableStatusCodes.
+// Other requests, such as the POST to /v3/networkdesign/buy, may create or change products, so they are only retried
+// when the request never reached the API (the connection could not be established) or when the API rejected it with
+// one of the UnsafeRetryableStatusCodes before processing it.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It doubles for each further retry, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of each backoff delay that is randomised.
+	Jitter float64
+
+	RetryableStatusCodes       []int
+	UnsafeRetryableStatusCodes []int
+	RetryableMethods           []string
+
+	// MaxRetryAfter caps how long a Retry-After header may delay a retry. If the API asks for a longer delay, the
+	// response is returned without retrying. Zero means Retry-After is honoured whatever its value.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most callers.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:                4,
+		MinBackoff:                 500 * time.Millisecond,
+		MaxBackoff:                 30 * time.Second,
+		Jitter:                     0.2,
+		RetryableStatusCodes:       []int{429, 500, 502, 503, 504},
+		UnsafeRetryableStatusCodes: []int{429},
+		RetryableMethods:           []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"},
+		MaxRetryAfter:              time.Minute,
+	}
+}
+
+// shouldRetry decides whether a request made on the given attempt should be tried again.
+func (r *RetryPolicy) shouldRetry(verb string, attempt int, response *http.Response, resErr error) bool {
+	if r == nil || attempt >= r.MaxAttempts {
+		return false
+	}
+
+	idempotent := slices.Contains(r.RetryableMethods, verb)
+
+	if resErr != nil {
+		return idempotent || isConnectionError(resErr)
+	}
+
+	if idempotent {
+		return slices.Contains(r.RetryableStatusCodes, response.StatusCode)
+	}
+
+	return slices.Contains(r.UnsafeRetryableStatusCodes, response.StatusCode)
+}
+
+// delay returns how long to wait before the next attempt, and false if the API asked for a longer wait than the
+// policy allows.
+func (r *RetryPolicy) delay(attempt int, response *http.Response) (time.Duration, bool) {
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if r.MaxRetryAfter > 0 && retryAfter > r.MaxRetryAfter {
+				return 0, false
+			}
+			return retryAfter, true
+		}
+	}
+
+	backoff := r.MinBackoff
+	for i := 1; i < attempt && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if r.MaxBackoff > 0 && backoff > r.MaxBackoff {
+		backoff = r.MaxBackoff
+	}
+
+	if r.Jitter > 0 {
+		backoff -= time.Duration(rand.Float64() * r.Jitter * float64(backoff))
+	}
+
+	return backoff, true
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isConnectionError reports whether err occurred before the request was sent, so that retrying it cannot repeat
+// a change that was already made.
+func isConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
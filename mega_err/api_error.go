@@ -0,0 +1,129 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mega_err
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// APIError is returned when the Megaport API responds with an unexpected status code. It carries the status code,
+// the fields of the API's error response and details of the request that failed.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	RequestID  string
+
+	Message string
+	Terms   string
+	Data    string
+
+	// Body holds the raw response body and Err the parse error when the error response could not be decoded.
+	Body string
+	Err  error
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf(ERR_PARSING_ERR_RESPONSE, e.StatusCode, e.Err.Error(), e.Body)
+	}
+
+	return e.Message + ": " + e.Data
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the status code of the error matches one of the status sentinel errors, e.g. ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+
+	return false
+}
+
+// Retryable reports whether repeating the request that caused the error may succeed.
+func (e *APIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// AsAPIError returns the *APIError in err's chain, if there is one.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+
+	return nil, false
+}
+
+// IsNotFound reports whether err is an API error with a 404 status code.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is an API error with a 401 status code.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err is an API error with a 403 status code.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict reports whether err is an API error with a 409 status code.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsRetryable reports whether err is a transient failure: an API error with a retryable status code, or a network
+// timeout.
+func IsRetryable(err error) bool {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.Retryable()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
@@ -14,6 +14,8 @@
 
 package mega_err
 
+import "errors"
+
 const ERR_PORT_PROVISION_TIMEOUT_EXCEED = "the port took too long to provision"
 const ERR_MCR_PROVISION_TIMEOUT_EXCEED = "the MCR took too long to provision"
 const ERR_MVE_PROVISION_TIMEOUT_EXCEED = "the MVE took too long to provision"
@@ -38,3 +40,72 @@ const ERR_PARSING_ERR_RESPONSE = "status code '%v' received from api and there h
 const ERR_PARTNER_PORT_NO_RESULTS = "sorry there were no results returned based on the given filters"
 const ERR_SESSION_TOKEN_STILL_EXIST = "it looks like the session was not removed and still exists, logout did not work"
 const ERR_MEGAPORT_URL_NOT_SET = "The variable megaport_url has not been set correctly"
+const ERR_NO_CREDENTIALS = "no Megaport API credentials have been configured"
+const ERR_UNKNOWN_ENVIRONMENT = "unknown Megaport environment"
+const ERR_INVALID_ENVIRONMENT = "invalid Megaport environment"
+const ERR_WAIT_TIMEOUT_EXCEED = "the product did not reach the expected state before the timeout"
+const ERR_EMPTY_ORDER_CONFIRMATION = "the order was accepted but the API did not confirm any services"
+const ERR_EMPTY_NETWORK_DESIGN = "the network design does not contain any products"
+const ERR_UNKNOWN_DESIGN_ITEM = "the VXC end is not part of the network design"
+const ERR_INVALID_ORDER = "invalid order"
+const ERR_PRODUCT_ALREADY_LOCKED = "the product is already locked, cannot lock"
+const ERR_PRODUCT_NOT_LOCKED = "the product is not locked, cannot unlock"
+const ERR_PRODUCT_ADMIN_LOCKED = "the product has been locked by Megaport and cannot be changed"
+const ERR_PRODUCT_INACTIVE = "the product has been cancelled or decommissioned"
+const ERR_WRONG_PRODUCT_TERM_CHANGE = "the contract term can only be changed for Ports, MCRs, MVEs and VXCs"
+const ERR_UNSUPPORTED_SNAPSHOT_VERSION = "the inventory snapshot was written by an unsupported version"
+const ERR_MISSING_PRODUCT_TYPE = "the product details returned by the API did not include a product type"
+
+// Sentinel errors for the messages above, for use with errors.Is. The error text of each sentinel matches its
+// constant so existing string comparisons keep working.
+var (
+	ErrPortProvisionTimeoutExceed = errors.New(ERR_PORT_PROVISION_TIMEOUT_EXCEED)
+	ErrMCRProvisionTimeoutExceed  = errors.New(ERR_MCR_PROVISION_TIMEOUT_EXCEED)
+	ErrMVEProvisionTimeoutExceed  = errors.New(ERR_MVE_PROVISION_TIMEOUT_EXCEED)
+	ErrVXCProvisionTimeoutExceed  = errors.New(ERR_VXC_PROVISION_TIMEOUT_EXCEED)
+
+	ErrVXCNotLive             = errors.New(ERR_VXC_NOT_LIVE)
+	ErrVXCUpdateTimeoutExceed = errors.New(ERR_VXC_UPDATE_TIMEOUT_EXCEED)
+	ErrWrongProductModify     = errors.New(ERR_WRONG_PRODUCT_MODIFY)
+	ErrNoAvailableVXCPorts    = errors.New(ERR_NO_AVAILABLE_VXC_PORTS)
+	ErrInvalidPartner         = errors.New(ERR_INVALID_PARTNER)
+	ErrTermNotValid           = errors.New(ERR_TERM_NOT_VALID)
+	ErrPortAlreadyLocked      = errors.New(ERR_PORT_ALREADY_LOCKED)
+	ErrPortNotLocked          = errors.New(ERR_PORT_NOT_LOCKED)
+	ErrPortNotLive            = errors.New(ERR_PORT_NOT_LIVE)
+	ErrMCRInvalidPortSpeed    = errors.New(ERR_MCR_INVALID_PORT_SPEED)
+	ErrMCRNotLive             = errors.New(ERR_MCR_NOT_LIVE)
+	ErrLocationNotFound       = errors.New(ERR_LOCATION_NOT_FOUND)
+	ErrNoMatchingLocations    = errors.New(ERR_NO_MATCHING_LOCATIONS)
+	ErrNoOTPKeyDefined        = errors.New(ERR_NO_OTP_KEY_DEFINED)
+	ErrPartnerPortNoResults   = errors.New(ERR_PARTNER_PORT_NO_RESULTS)
+	ErrSessionTokenStillExist = errors.New(ERR_SESSION_TOKEN_STILL_EXIST)
+	ErrMegaportURLNotSet      = errors.New(ERR_MEGAPORT_URL_NOT_SET)
+	ErrNoCredentials          = errors.New(ERR_NO_CREDENTIALS)
+	ErrUnknownEnvironment     = errors.New(ERR_UNKNOWN_ENVIRONMENT)
+	ErrInvalidEnvironment     = errors.New(ERR_INVALID_ENVIRONMENT)
+	ErrWaitTimeoutExceed      = errors.New(ERR_WAIT_TIMEOUT_EXCEED)
+	ErrEmptyOrderConfirmation = errors.New(ERR_EMPTY_ORDER_CONFIRMATION)
+	ErrEmptyNetworkDesign     = errors.New(ERR_EMPTY_NETWORK_DESIGN)
+	ErrUnknownDesignItem      = errors.New(ERR_UNKNOWN_DESIGN_ITEM)
+	ErrInvalidOrder           = errors.New(ERR_INVALID_ORDER)
+	ErrProductAlreadyLocked   = errors.New(ERR_PRODUCT_ALREADY_LOCKED)
+	ErrProductNotLocked       = errors.New(ERR_PRODUCT_NOT_LOCKED)
+	ErrProductAdminLocked     = errors.New(ERR_PRODUCT_ADMIN_LOCKED)
+	ErrProductInactive        = errors.New(ERR_PRODUCT_INACTIVE)
+	ErrWrongProductTermChange = errors.New(ERR_WRONG_PRODUCT_TERM_CHANGE)
+
+	ErrUnsupportedSnapshotVersion = errors.New(ERR_UNSUPPORTED_SNAPSHOT_VERSION)
+	ErrMissingProductType         = errors.New(ERR_MISSING_PRODUCT_TYPE)
+)
+
+// Sentinel errors matched by an *APIError according to its HTTP status code, e.g. errors.Is(err, ErrNotFound).
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServerError  = errors.New("server error")
+)
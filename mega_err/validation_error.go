@@ -0,0 +1,91 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mega_err
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError is a problem with a single field of an order or update, found before it was sent to the API.
+type FieldError struct {
+	// Field is the path of the field in the JSON sent to the API, e.g. "aEnd.partnerConfig.interfaces[0].ipAddresses[1]".
+	Field   string
+	Value   interface{}
+	Message string
+
+	// Err is the sentinel error for the problem, if there is one, e.g. ErrTermNotValid.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when an order or update fails local validation. It holds every problem found, not only
+// the first. errors.Is(err, ErrInvalidOrder) matches any ValidationError, and errors.Is also matches the sentinel
+// errors of its fields, e.g. ErrTermNotValid.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fieldErr.Error())
+	}
+
+	if len(messages) == 1 {
+		return ERR_INVALID_ORDER + ": " + messages[0]
+	}
+	return fmt.Sprintf("%s: %d problems: %s", ERR_INVALID_ORDER, len(messages), strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidOrder
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
+
+// Field returns the error for the field with the given path, or nil if the field is valid.
+func (e *ValidationError) Field(field string) *FieldError {
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Field == field {
+			return fieldErr
+		}
+	}
+	return nil
+}
+
+// AsValidationError returns the *ValidationError in err's chain, if there is one.
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+
+	return nil, false
+}
@@ -12,4 +12,287 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package megaport is the entry point of the Megaport Go Library. NewClient wires up the configuration,
+// authentication and every service, so that a single Client can be used to manage Megaport products:
+//
+//	client, err := megaport.NewClient(
+//		megaport.WithEnvironment(config.EnvironmentStaging),
+//		megaport.WithCredentials(accessKey, secretKey),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	ports, err := client.Ports.GetPortsWithContext(ctx)
 package megaport
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/service/authentication"
+	"github.com/megaport/megaportgo/service/inventory"
+	"github.com/megaport/megaportgo/service/location"
+	"github.com/megaport/megaportgo/service/mcr"
+	"github.com/megaport/megaportgo/service/mve"
+	"github.com/megaport/megaportgo/service/partner"
+	"github.com/megaport/megaportgo/service/port"
+	"github.com/megaport/megaportgo/service/product"
+	"github.com/megaport/megaportgo/service/vxc"
+)
+
+// DefaultEndpoint is the Megaport API used when no endpoint is given.
+const DefaultEndpoint = "https://api.megaport.com/"
+
+// Client gives access to every Megaport service. All services share the Client's Config.
+type Client struct {
+	Config *config.Config
+	Auth   *authentication.Authentication
+
+	Products  *product.Product
+	Ports     *port.Port
+	VXCs      *vxc.VXC
+	MCRs      *mcr.MCR
+	MVEs      *mve.MVE
+	Locations *location.Location
+	Partners  *partner.Partner
+	Inventory *inventory.Inventory
+
+	httpClient *http.Client
+	timeout    time.Duration
+	accessKey  string
+	secretKey  string
+
+	credentialsProvider authentication.CredentialsProvider
+	tokenStore          authentication.TokenStore
+
+	// environmentSet records that the endpoint or environment was chosen explicitly, taking precedence over an
+	// environment named by the credentials provider.
+	environmentSet bool
+
+	// err is the first error returned by an option.
+	err error
+}
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithLogger sets the logger used by every service.
+func WithLogger(logger config.Logger) ClientOption {
+	return func(c *Client) {
+		c.Config.Log = logger
+	}
+}
+
+// WithEndpoint sets the base URL of the Megaport API, e.g. "https://api-staging.megaport.com/". Use
+// WithEnvironment for APIs other than Megaport's own.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.Config.Endpoint = endpoint
+		c.Config.Environment = nil
+		c.environmentSet = true
+	}
+}
+
+// WithEnvironment sets the environment, which determines both the API endpoint and the OAuth token URL.
+func WithEnvironment(env config.Environment) ClientOption {
+	return func(c *Client) {
+		c.setErr(c.Config.SetEnvironment(env))
+		c.environmentSet = true
+	}
+}
+
+// WithEnvironmentFromEnv selects the environment using the MEGAPORT_ENVIRONMENT, MEGAPORT_API_ENDPOINT and
+// MEGAPORT_TOKEN_URL environment variables. See config.EnvironmentFromEnv.
+func WithEnvironmentFromEnv() ClientOption {
+	return func(c *Client) {
+		env, err := config.EnvironmentFromEnv()
+		if err != nil {
+			c.setErr(err)
+			return
+		}
+		c.setErr(c.Config.SetEnvironment(env))
+		c.environmentSet = true
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for API calls.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCredentials sets the API key and secret used to obtain bearer tokens. API calls are authenticated
+// automatically, with tokens refreshed before they expire or when the API rejects them.
+func WithCredentials(accessKey, secretKey string) ClientOption {
+	return func(c *Client) {
+		c.accessKey = accessKey
+		c.secretKey = secretKey
+	}
+}
+
+// WithCredentialsProvider retrieves the API key and secret from provider when the Client is created. If the
+// credentials name an environment, it is used unless WithEndpoint or WithEnvironment is also given. Credentials
+// given with WithCredentials take precedence.
+func WithCredentialsProvider(provider authentication.CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentialsProvider = provider
+	}
+}
+
+// WithProfile reads credentials from the named profile of the shared credentials file, ~/.megaport/credentials by
+// default. See authentication.ProfileCredentialsProvider.
+func WithProfile(profile string) ClientOption {
+	return WithCredentialsProvider(authentication.NewProfileCredentialsProvider(profile))
+}
+
+// WithTokenStore persists bearer tokens in store, so that they are reused until they expire by other clients and
+// processes using the same credentials. See authentication.NewFileTokenStore.
+func WithTokenStore(store authentication.TokenStore) ClientOption {
+	return func(c *Client) {
+		c.tokenStore = store
+	}
+}
+
+// WithSessionToken sets a bearer token obtained elsewhere, removing the need to call Login.
+func WithSessionToken(token string) ClientOption {
+	return func(c *Client) {
+		c.Config.SessionToken = token
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with API calls.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.Config.UserAgent = userAgent
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed API calls. Use config.DefaultRetryPolicy() for sensible
+// defaults.
+func WithRetryPolicy(policy *config.RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.Config.Retry = policy
+	}
+}
+
+// WithRateLimit limits API calls to requestsPerSecond on average, with bursts of up to burst calls.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.Config.RateLimiter = config.NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithTimeout sets the time limit for each HTTP request, including reading the response.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// NewClient returns a Client configured by the given options. When credentials are given with WithCredentials or a
+// credentials provider, API calls are authenticated with bearer tokens that are obtained and refreshed as needed.
+// Otherwise a token must be supplied using WithSessionToken.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.WarnLevel)
+
+	c := &Client{
+		Config: &config.Config{
+			Log:      logger,
+			Endpoint: DefaultEndpoint,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	if c.credentialsProvider != nil && c.accessKey == "" {
+		creds, err := c.credentialsProvider.Retrieve()
+		if err != nil {
+			return nil, err
+		}
+
+		c.accessKey = creds.AccessKey
+		c.secretKey = creds.SecretKey
+
+		if creds.Environment != nil && !c.environmentSet {
+			if err := c.Config.SetEnvironment(*creds.Environment); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if c.Config.Endpoint == "" {
+		return nil, mega_err.ErrMegaportURLNotSet
+	}
+
+	if c.accessKey != "" {
+		if _, err := c.Config.TokenURL(); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = config.NewHttpClient()
+	}
+
+	if c.timeout > 0 {
+		// Copy the client so that a client supplied by the caller is not modified.
+		timeoutClient := *httpClient
+		timeoutClient.Timeout = c.timeout
+		httpClient = &timeoutClient
+	}
+
+	c.Config.Client = httpClient
+	c.Auth = authentication.New(c.Config)
+	c.Auth.TokenStore = c.tokenStore
+
+	if c.accessKey != "" {
+		c.Auth.SetCredentials(c.accessKey, c.secretKey)
+
+		// The token requests made by Auth pass through the transport unchanged.
+		authClient := *httpClient
+		authClient.Transport = authentication.NewTransport(c.Auth, httpClient.Transport)
+		c.Config.Client = &authClient
+	}
+
+	c.Products = product.New(c.Config)
+	c.Ports = port.New(c.Config)
+	c.VXCs = vxc.New(c.Config)
+	c.MCRs = mcr.New(c.Config)
+	c.MVEs = mve.New(c.Config)
+	c.Locations = location.New(c.Config)
+	c.Partners = partner.New(c.Config)
+	c.Inventory = inventory.New(c.Config)
+
+	return c, nil
+}
+
+func (c *Client) setErr(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// Login authenticates with the credentials given to WithCredentials or a credentials provider, storing the session
+// token for all services. Calling Login is optional, as tokens are obtained when they are first needed; it allows
+// credentials to be checked up front.
+func (c *Client) Login(ctx context.Context) error {
+	if c.accessKey == "" || c.secretKey == "" {
+		return mega_err.ErrNoCredentials
+	}
+
+	_, err := c.Auth.LoginOauthWithContext(ctx, c.accessKey, c.secretKey)
+	return err
+}
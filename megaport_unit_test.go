@@ -0,0 +1,172 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package megaport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/service/authentication"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientDefaults(t *testing.T) {
+	client, err := NewClient()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, DefaultEndpoint, client.Config.Endpoint)
+	assert.NotNil(t, client.Config.Client)
+
+	// Every service shares the client's Config.
+	assert.Same(t, client.Config, client.Ports.Config)
+	assert.Same(t, client.Config, client.VXCs.Config)
+	assert.Same(t, client.Config, client.MCRs.Config)
+	assert.Same(t, client.Config, client.MVEs.Config)
+	assert.Same(t, client.Config, client.Locations.Config)
+	assert.Same(t, client.Config, client.Partners.Config)
+	assert.Same(t, client.Config, client.Inventory.Config)
+	assert.Same(t, client.Config, client.Auth.Config)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	userAgent := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"message":"ok","terms":"","data":[]}`))
+	}))
+	defer server.Close()
+
+	httpClient := server.Client()
+	policy := config.DefaultRetryPolicy()
+
+	client, err := NewClient(
+		WithEndpoint(server.URL),
+		WithHTTPClient(httpClient),
+		WithUserAgent("test-agent/1.0"),
+		WithRetryPolicy(policy),
+		WithRateLimit(10, 5),
+		WithTimeout(5*time.Second),
+		WithSessionToken("token"),
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Same(t, policy, client.Config.Retry)
+	assert.NotNil(t, client.Config.RateLimiter)
+	assert.Equal(t, "token", client.Config.SessionToken)
+
+	// The timeout is applied to a copy of the caller's HTTP client.
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+	assert.Equal(t, 5*time.Second, client.Config.Client.(*http.Client).Timeout)
+
+	_, err = client.Locations.GetAllLocationsWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "test-agent/1.0", userAgent)
+}
+
+func TestNewClientNoEndpoint(t *testing.T) {
+	_, err := NewClient(WithEndpoint(""))
+	assert.ErrorIs(t, err, mega_err.ErrMegaportURLNotSet)
+}
+
+func TestLoginWithoutCredentials(t *testing.T) {
+	client, err := NewClient()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.ErrorIs(t, client.Login(context.Background()), mega_err.ErrNoCredentials)
+}
+
+func TestClientCustomEnvironment(t *testing.T) {
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			tokenRequests++
+			w.Write([]byte(`{"access_token":"local-token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+
+		assert.Equal(t, "Bearer local-token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"message":"ok","terms":"","data":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithEnvironment(config.CustomEnvironment(server.URL, server.URL+"/oauth2/token")),
+		WithCredentials("access", "secret"),
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = client.Locations.GetAllLocationsWithContext(context.Background())
+	assert.NoError(t, err)
+	_, err = client.Partners.GetAllPartnerMegaportsWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestNewClientUnknownEndpoint(t *testing.T) {
+	_, err := NewClient(WithEndpoint("https://api.example.com/"), WithCredentials("access", "secret"))
+	assert.ErrorIs(t, err, mega_err.ErrUnknownEnvironment)
+
+	_, err = NewClient(WithEnvironment(config.CustomEnvironment("api.example.com", "")))
+	assert.ErrorIs(t, err, mega_err.ErrInvalidEnvironment)
+}
+
+func TestNewClientWithProfile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	contents := "[staging]\naccess_key = staging-key\nsecret_key = staging-secret\nenvironment = staging\n"
+	if err := os.WriteFile(filename, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &authentication.ProfileCredentialsProvider{Filename: filename, Profile: "staging"}
+
+	client, err := NewClient(WithCredentialsProvider(provider))
+	assert.NoError(t, err)
+	assert.Equal(t, config.EnvironmentStaging.APIEndpoint, client.Config.Endpoint)
+	assert.Equal(t, "staging-key", client.accessKey)
+
+	// An explicit environment takes precedence over the profile's.
+	client, err = NewClient(WithCredentialsProvider(provider), WithEnvironment(config.EnvironmentProduction))
+	assert.NoError(t, err)
+	assert.Equal(t, config.EnvironmentProduction.APIEndpoint, client.Config.Endpoint)
+
+	_, err = NewClient(WithCredentialsProvider(&authentication.ProfileCredentialsProvider{Filename: filename}))
+	assert.ErrorIs(t, err, mega_err.ErrNoCredentials)
+}
+
+func TestNewClientWithTokenStore(t *testing.T) {
+	store := &authentication.FileTokenStore{Dir: t.TempDir()}
+
+	client, err := NewClient(WithCredentials("access", "secret"), WithTokenStore(store))
+	assert.NoError(t, err)
+	assert.Equal(t, store, client.Auth.TokenStore)
+}
@@ -21,6 +21,7 @@
 package authentication
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -28,50 +29,238 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/types"
 )
 
+// DefaultRefreshWindow is how long before it expires a cached token is refreshed by Token.
+const DefaultRefreshWindow = time.Minute
+
 type Authentication struct {
 	*config.Config
 
+	// RefreshWindow is how long before it expires the bearer token is refreshed by Token.
+	RefreshWindow time.Duration
+
+	// TokenStore, if set, persists bearer tokens so that they are reused by other Authentications and processes
+	// using the same credentials and environment. It must be set before the first login.
+	TokenStore TokenStore
+
+	mu          sync.Mutex
 	bearerToken string
 	tokenExpiry time.Time
+	accessKey   string
+	secretKey   string
+	login       *loginCall
+	permissions types.Permissions
+}
+
+// loginCall is a token request in progress, shared by every caller that needs a new token at the same time.
+type loginCall struct {
+	done  chan struct{}
+	token string
+	err   error
 }
 
+// New returns an Authentication for the Config, creating an HTTP client for it if it does not already have one.
 func New(cfg *config.Config) *Authentication {
-	cfg.Client = config.NewHttpClient()
+	if cfg.Client == nil {
+		cfg.Client = config.NewHttpClient()
+	}
 	return &Authentication{
-		Config: cfg,
+		Config:        cfg,
+		RefreshWindow: DefaultRefreshWindow,
 	}
 }
 
+// SetCredentials sets the API key and API secret key used by Token to obtain bearer tokens. Changing the credentials
+// discards the cached bearer token, so that the next token is obtained with the new credentials.
+func (auth *Authentication) SetCredentials(accessKey, secretKey string) {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	if accessKey != auth.accessKey || secretKey != auth.secretKey {
+		auth.bearerToken = ""
+		auth.tokenExpiry = time.Time{}
+	}
+
+	auth.accessKey = accessKey
+	auth.secretKey = secretKey
+}
+
 // LoginOauth performs an OAuth-style logi using an API key and API
 // secret key. It returns the bearer token or an error if the login
 // was unsuccessful.
 func (auth *Authentication) LoginOauth(accessKey, secretKey string) (string, error) {
+	return auth.LoginOauthWithContext(context.Background(), accessKey, secretKey)
+}
+
+// LoginOauthWithContext is the same as LoginOauth, using the supplied context for the token request.
+func (auth *Authentication) LoginOauthWithContext(ctx context.Context, accessKey, secretKey string) (string, error) {
 	auth.Log.Debugln("Creating Session for:", accessKey)
+	auth.SetCredentials(accessKey, secretKey)
 
 	// Shortcut if we've already authenticated.
-	if time.Now().Before(auth.tokenExpiry) {
-		return auth.bearerToken, nil
+	token, err := auth.token(ctx, 0)
+	if err != nil {
+		return "", err
+	}
+
+	auth.SetSessionToken(token)
+	return token, nil
+}
+
+// Token returns a valid bearer token, logging in with the credentials set by SetCredentials or LoginOauth when the
+// cached token expires within RefreshWindow. Concurrent callers share a single token request. Token is safe for
+// concurrent use.
+func (auth *Authentication) Token(ctx context.Context) (string, error) {
+	return auth.token(ctx, auth.RefreshWindow)
+}
+
+// InvalidateToken discards the cached bearer token if it is still the given token, e.g. after the API rejected it,
+// so that the next call to Token logs in again.
+func (auth *Authentication) InvalidateToken(token string) {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	if auth.bearerToken == token {
+		auth.bearerToken = ""
+		auth.tokenExpiry = time.Time{}
+	}
+
+	if auth.TokenStore == nil || auth.accessKey == "" {
+		return
+	}
+
+	tokenURL, err := auth.Config.TokenURL()
+	if err != nil {
+		return
+	}
+
+	key := TokenStoreKey(auth.accessKey, tokenURL)
+	if cached, ok, err := auth.TokenStore.Load(key); err == nil && ok && cached.AccessToken == token {
+		if err := auth.TokenStore.Delete(key); err != nil {
+			auth.Log.Warnln("Unable to delete cached token:", err)
+		}
+	}
+}
+
+// token returns the cached bearer token if it is valid for longer than window, otherwise it logs in again.
+func (auth *Authentication) token(ctx context.Context, window time.Duration) (string, error) {
+	auth.mu.Lock()
+
+	if auth.bearerToken != "" && time.Now().Add(window).Before(auth.tokenExpiry) {
+		token := auth.bearerToken
+		auth.mu.Unlock()
+		return token, nil
+	}
+
+	if call := auth.login; call != nil {
+		auth.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.token, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
 	}
 
+	if auth.accessKey == "" || auth.secretKey == "" {
+		auth.mu.Unlock()
+		return "", mega_err.ErrNoCredentials
+	}
+
+	call := &loginCall{done: make(chan struct{})}
+	auth.login = call
+	accessKey, secretKey := auth.accessKey, auth.secretKey
+	auth.mu.Unlock()
+
+	cached, err := auth.fetchToken(ctx, accessKey, secretKey, window)
+
+	auth.mu.Lock()
+	if err == nil {
+		auth.tokenExpiry = cached.Expiry
+		auth.bearerToken = cached.AccessToken
+		call.token = cached.AccessToken
+	}
+	call.err = err
+	auth.login = nil
+	close(call.done)
+	auth.mu.Unlock()
+
+	return call.token, call.err
+}
+
+// fetchToken returns a token from the TokenStore if it holds one valid for longer than window, otherwise it requests
+// a new token and saves it to the store. Failures to read or write the store are logged rather than returned, as the
+// store only saves logins.
+func (auth *Authentication) fetchToken(ctx context.Context, accessKey, secretKey string, window time.Duration) (CachedToken, error) {
+	if auth.TokenStore == nil {
+		return auth.loginToken(ctx, accessKey, secretKey)
+	}
+
+	tokenURL, err := auth.Config.TokenURL()
+	if err != nil {
+		return CachedToken{}, err
+	}
+	key := TokenStoreKey(accessKey, tokenURL)
+
+	if locker, ok := auth.TokenStore.(TokenLocker); ok {
+		unlock, err := locker.Lock(ctx, key)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return CachedToken{}, ctxErr
+		} else if err != nil {
+			auth.Log.Warnln("Unable to lock token store:", err)
+		} else {
+			defer unlock()
+		}
+	}
+
+	if cached, ok, err := auth.TokenStore.Load(key); err != nil {
+		auth.Log.Warnln("Unable to read cached token:", err)
+	} else if ok && cached.ValidFor(window) {
+		auth.Log.Debugln("Using cached token")
+		return cached, nil
+	}
+
+	cached, err := auth.loginToken(ctx, accessKey, secretKey)
+	if err != nil {
+		return cached, err
+	}
+
+	if err := auth.TokenStore.Save(key, cached); err != nil {
+		auth.Log.Warnln("Unable to cache token:", err)
+	}
+
+	return cached, nil
+}
+
+// loginToken requests a new bearer token, returning it with its expiry time.
+func (auth *Authentication) loginToken(ctx context.Context, accessKey, secretKey string) (CachedToken, error) {
+	authResponse, err := auth.requestToken(ctx, accessKey, secretKey)
+	if err != nil {
+		return CachedToken{}, err
+	}
+
+	return NewCachedToken(authResponse, time.Now()), nil
+}
+
+// requestToken requests a new bearer token from the OAuth token endpoint.
+func (auth *Authentication) requestToken(ctx context.Context, accessKey, secretKey string) (types.AccessTokenResponse, error) {
+	authResponse := types.AccessTokenResponse{}
+
 	// Encode the client ID and client secret to create Basic Authentication
 	authHeader := base64.StdEncoding.EncodeToString([]byte(accessKey + ":" + secretKey))
 
 	// Set the URL for the token endpoint
-	var tokenURL string
-	if auth.Config.Endpoint == "https://api.megaport.com/" {
-		tokenURL = "https://auth-m2m.megaport.com/oauth2/token"
-	} else if auth.Config.Endpoint == "https://api-staging.megaport.com/" {
-		tokenURL = "https://oauth-m2m-staging.auth.ap-southeast-2.amazoncognito.com/oauth2/token"
-	} else if auth.Config.Endpoint == "https://api-uat.megaport.com/" {
-		tokenURL = "https://oauth-m2m-uat.auth.ap-southeast-2.amazoncognito.com/oauth2/token"
-	} else if auth.Config.Endpoint == "https://api-uat2.megaport.com/" {
-		tokenURL = "https://oauth-m2m-uat2.auth.ap-southeast-2.amazoncognito.com/oauth2/token"
+	tokenURL, urlErr := auth.Config.TokenURL()
+	if urlErr != nil {
+		return authResponse, urlErr
 	}
 
 	// Create form data for the request body
@@ -79,43 +268,41 @@ func (auth *Authentication) LoginOauth(accessKey, secretKey string) (string, err
 	data.Set("grant_type", "client_credentials")
 
 	// Create an HTTP request
-	req, _ := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	req, reqErr := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data.Encode()))
+	if reqErr != nil {
+		return authResponse, reqErr
+	}
 
 	// Set the request headers
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Authorization", "Basic "+authHeader)
+	if auth.UserAgent != "" {
+		req.Header.Set("User-Agent", auth.UserAgent)
+	}
 
 	// Create an HTTP client and send the request
 	auth.Log.Debugln("Login request to:", tokenURL)
 	resp, resErr := auth.Client.Do(req)
 	if resErr != nil {
-		return "", resErr
+		return authResponse, resErr
 	}
 	defer resp.Body.Close()
 
 	// Read the response body
 	body, fileErr := io.ReadAll(resp.Body)
 	if fileErr != nil {
-		return "", fileErr
+		return authResponse, fileErr
 	}
 
 	// Parse the response JSON to extract the access token and expiration time
-	authResponse := types.AccessTokenResponse{}
 	if parseErr := json.Unmarshal(body, &authResponse); parseErr != nil {
-		return "", parseErr
+		return authResponse, parseErr
 	}
 
 	if authResponse.Error != "" {
-		return "", errors.New("authentication error: " + authResponse.Error)
+		return authResponse, errors.New("authentication error: " + authResponse.Error)
 	}
 
-	// Calculate the token expiration time
-	auth.tokenExpiry = time.Now().Add(time.Duration(authResponse.ExpiresIn) * time.Second)
-
-	// Store the access token
-	auth.bearerToken = authResponse.AccessToken
-	auth.SessionToken = authResponse.AccessToken
-
 	auth.Log.Debugln("session established")
-	return auth.bearerToken, nil
+	return authResponse, nil
 }
@@ -92,3 +92,27 @@ func TestLoginOauth(t *testing.T) {
 
 	logger.Info(token)
 }
+
+func TestLoginMFA(t *testing.T) {
+	if username == "" || password == "" {
+		t.Skip("MEGAPORT_USERNAME and MEGAPORT_PASSWORD environment variables not set.")
+	}
+
+	auth := New(&config.Config{Log: logger, Endpoint: MEGAPORTURL})
+
+	var token string
+	var loginErr error
+	if otp != "" {
+		token, loginErr = auth.LoginMFA(username, password, otp)
+	} else {
+		token, loginErr = auth.Login(username, password, "")
+	}
+
+	assert.NoError(t, loginErr)
+	assert.NotEmpty(t, token)
+	assert.NotEmpty(t, auth.Permissions())
+
+	logoutErr := auth.Logout()
+	assert.NoError(t, logoutErr)
+	assert.Empty(t, auth.SessionToken)
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+)
+
+// Environment variables read by the credentials providers.
+const (
+	ENV_ACCESS_KEY       = "MEGAPORT_ACCESS_KEY"
+	ENV_SECRET_KEY       = "MEGAPORT_SECRET_KEY"
+	ENV_PROFILE          = "MEGAPORT_PROFILE"
+	ENV_CREDENTIALS_FILE = "MEGAPORT_CREDENTIALS_FILE"
+)
+
+// DEFAULT_PROFILE is the profile read from the credentials file when no profile is named.
+const DEFAULT_PROFILE = "default"
+
+// Credentials are a Megaport API key and secret, with the environment they belong to if the provider knows it.
+type Credentials struct {
+	AccessKey   string
+	SecretKey   string
+	Environment *config.Environment
+
+	// Source names the provider the credentials came from.
+	Source string
+}
+
+// CredentialsProvider supplies API credentials. Providers return mega_err.ErrNoCredentials when they have none, so
+// that a ChainCredentialsProvider can try the next provider.
+type CredentialsProvider interface {
+	Retrieve() (Credentials, error)
+}
+
+// StaticCredentialsProvider supplies credentials given explicitly.
+type StaticCredentialsProvider struct {
+	Credentials Credentials
+}
+
+// NewStaticCredentialsProvider returns a provider for the given API key and secret.
+func NewStaticCredentialsProvider(accessKey, secretKey string) *StaticCredentialsProvider {
+	return &StaticCredentialsProvider{Credentials: Credentials{AccessKey: accessKey, SecretKey: secretKey}}
+}
+
+func (p *StaticCredentialsProvider) Retrieve() (Credentials, error) {
+	if p.Credentials.AccessKey == "" || p.Credentials.SecretKey == "" {
+		return Credentials{}, mega_err.ErrNoCredentials
+	}
+
+	creds := p.Credentials
+	creds.Source = "static"
+	return creds, nil
+}
+
+// EnvCredentialsProvider supplies credentials from the MEGAPORT_ACCESS_KEY and MEGAPORT_SECRET_KEY environment
+// variables.
+type EnvCredentialsProvider struct{}
+
+func (p *EnvCredentialsProvider) Retrieve() (Credentials, error) {
+	accessKey := os.Getenv(ENV_ACCESS_KEY)
+	secretKey := os.Getenv(ENV_SECRET_KEY)
+
+	if accessKey == "" || secretKey == "" {
+		return Credentials{}, mega_err.ErrNoCredentials
+	}
+
+	return Credentials{AccessKey: accessKey, SecretKey: secretKey, Source: "environment"}, nil
+}
+
+// ProfileCredentialsProvider supplies credentials from a named profile in a shared credentials file, by default
+// ~/.megaport/credentials. Each profile is a section holding the API key and secret, and optionally the environment
+// the key belongs to:
+//
+//	[default]
+//	access_key = ...
+//	secret_key = ...
+//
+//	[staging]
+//	access_key = ...
+//	secret_key = ...
+//	environment = staging
+//
+//	[local]
+//	access_key = ...
+//	secret_key = ...
+//	api_endpoint = http://localhost:8080
+//	token_url = http://localhost:8080/oauth2/token
+type ProfileCredentialsProvider struct {
+	// Filename is the credentials file. If empty, MEGAPORT_CREDENTIALS_FILE or ~/.megaport/credentials is used.
+	Filename string
+
+	// Profile is the profile to read. If empty, MEGAPORT_PROFILE or "default" is used.
+	Profile string
+}
+
+// NewProfileCredentialsProvider returns a provider reading profile from the default credentials file.
+func NewProfileCredentialsProvider(profile string) *ProfileCredentialsProvider {
+	return &ProfileCredentialsProvider{Profile: profile}
+}
+
+func (p *ProfileCredentialsProvider) Retrieve() (Credentials, error) {
+	filename, err := p.filename()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	profile := p.profile()
+
+	profiles, err := readCredentialsFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return Credentials{}, mega_err.ErrNoCredentials
+	} else if err != nil {
+		return Credentials{}, err
+	}
+
+	values, ok := profiles[profile]
+	if !ok {
+		return Credentials{}, fmt.Errorf("%w: profile %q not found in %s", mega_err.ErrNoCredentials, profile, filename)
+	}
+
+	creds := Credentials{
+		AccessKey: values["access_key"],
+		SecretKey: values["secret_key"],
+		Source:    "profile " + profile,
+	}
+
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("profile %q in %s must set both access_key and secret_key", profile, filename)
+	}
+
+	if values["api_endpoint"] != "" || values["token_url"] != "" {
+		env := config.CustomEnvironment(values["api_endpoint"], values["token_url"])
+		if err := env.Validate(); err != nil {
+			return Credentials{}, fmt.Errorf("profile %q in %s: %w", profile, filename, err)
+		}
+		creds.Environment = &env
+	} else if values["environment"] != "" {
+		env, err := config.EnvironmentByName(values["environment"])
+		if err != nil {
+			return Credentials{}, fmt.Errorf("profile %q in %s: %w", profile, filename, err)
+		}
+		creds.Environment = &env
+	}
+
+	return creds, nil
+}
+
+func (p *ProfileCredentialsProvider) filename() (string, error) {
+	if p.Filename != "" {
+		return p.Filename, nil
+	}
+
+	if filename := os.Getenv(ENV_CREDENTIALS_FILE); filename != "" {
+		return filename, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".megaport", "credentials"), nil
+}
+
+func (p *ProfileCredentialsProvider) profile() string {
+	if p.Profile != "" {
+		return p.Profile
+	}
+
+	if profile := os.Getenv(ENV_PROFILE); profile != "" {
+		return profile
+	}
+
+	return DEFAULT_PROFILE
+}
+
+// readCredentialsFile parses a credentials file into its profiles' key/value pairs. Blank lines and lines starting
+// with '#' or ';' are ignored.
+func readCredentialsFile(filename string) (map[string]map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			current = map[string]string{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found || current == nil {
+			return nil, fmt.Errorf("%s:%d: expected a [profile] heading or a key = value pair", filename, lineNumber)
+		}
+
+		current[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+
+	return profiles, scanner.Err()
+}
+
+// ChainCredentialsProvider tries each of its providers in turn, returning the first credentials found.
+type ChainCredentialsProvider struct {
+	Providers []CredentialsProvider
+}
+
+// NewChainCredentialsProvider returns a provider trying each of providers in turn.
+func NewChainCredentialsProvider(providers ...CredentialsProvider) *ChainCredentialsProvider {
+	return &ChainCredentialsProvider{Providers: providers}
+}
+
+// NewDefaultCredentialsChain returns the standard chain: the MEGAPORT_ACCESS_KEY and MEGAPORT_SECRET_KEY
+// environment variables, then the given profile of the shared credentials file.
+func NewDefaultCredentialsChain(profile string) *ChainCredentialsProvider {
+	return NewChainCredentialsProvider(&EnvCredentialsProvider{}, NewProfileCredentialsProvider(profile))
+}
+
+func (p *ChainCredentialsProvider) Retrieve() (Credentials, error) {
+	for _, provider := range p.Providers {
+		creds, err := provider.Retrieve()
+		if err == nil {
+			return creds, nil
+		}
+
+		if !errors.Is(err, mega_err.ErrNoCredentials) {
+			return Credentials{}, err
+		}
+	}
+
+	return Credentials{}, mega_err.ErrNoCredentials
+}
+
+// LoginWithProvider logs in with credentials retrieved from provider. If the credentials name an environment, the
+// Config is pointed at it first.
+func (auth *Authentication) LoginWithProvider(ctx context.Context, provider CredentialsProvider) (string, error) {
+	creds, err := provider.Retrieve()
+	if err != nil {
+		return "", err
+	}
+
+	auth.Log.Debugln("Using credentials from", creds.Source)
+
+	if creds.Environment != nil {
+		if err := auth.Config.SetEnvironment(*creds.Environment); err != nil {
+			return "", err
+		}
+	}
+
+	return auth.LoginOauthWithContext(ctx, creds.AccessKey, creds.SecretKey)
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_CREDENTIALS_FILE = `
+# Shared Megaport credentials
+[default]
+access_key = default-key
+secret_key = default-secret
+
+[staging]
+access_key = staging-key
+secret_key = staging-secret
+environment = staging
+
+; A local mock of the API
+[local]
+access_key = local-key
+secret_key = local-secret
+api_endpoint = http://localhost:8080/
+token_url = http://localhost:8080/oauth2/token
+
+[incomplete]
+access_key = incomplete-key
+`
+
+func writeCredentialsFile(t *testing.T, contents string) string {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(filename, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestProfileCredentialsProvider(t *testing.T) {
+	filename := writeCredentialsFile(t, TEST_CREDENTIALS_FILE)
+
+	creds, err := (&ProfileCredentialsProvider{Filename: filename}).Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "default-key", creds.AccessKey)
+	assert.Equal(t, "default-secret", creds.SecretKey)
+	assert.Nil(t, creds.Environment)
+
+	creds, err = (&ProfileCredentialsProvider{Filename: filename, Profile: "staging"}).Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "staging-key", creds.AccessKey)
+	assert.Equal(t, config.EnvironmentStaging, *creds.Environment)
+
+	creds, err = (&ProfileCredentialsProvider{Filename: filename, Profile: "local"}).Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/", creds.Environment.APIEndpoint)
+	assert.Equal(t, "http://localhost:8080/oauth2/token", creds.Environment.TokenURL)
+
+	_, err = (&ProfileCredentialsProvider{Filename: filename, Profile: "incomplete"}).Retrieve()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, mega_err.ErrNoCredentials)
+
+	_, err = (&ProfileCredentialsProvider{Filename: filename, Profile: "missing"}).Retrieve()
+	assert.ErrorIs(t, err, mega_err.ErrNoCredentials)
+
+	_, err = (&ProfileCredentialsProvider{Filename: filepath.Join(t.TempDir(), "missing")}).Retrieve()
+	assert.ErrorIs(t, err, mega_err.ErrNoCredentials)
+}
+
+func TestProfileCredentialsProviderFromEnv(t *testing.T) {
+	t.Setenv(ENV_CREDENTIALS_FILE, writeCredentialsFile(t, TEST_CREDENTIALS_FILE))
+	t.Setenv(ENV_PROFILE, "staging")
+
+	creds, err := (&ProfileCredentialsProvider{}).Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "staging-key", creds.AccessKey)
+}
+
+func TestProfileCredentialsProviderMalformed(t *testing.T) {
+	filename := writeCredentialsFile(t, "access_key = orphan\n")
+
+	_, err := (&ProfileCredentialsProvider{Filename: filename}).Retrieve()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, mega_err.ErrNoCredentials)
+}
+
+func TestChainCredentialsProvider(t *testing.T) {
+	t.Setenv(ENV_CREDENTIALS_FILE, writeCredentialsFile(t, TEST_CREDENTIALS_FILE))
+	t.Setenv(ENV_PROFILE, "")
+	t.Setenv(ENV_ACCESS_KEY, "")
+	t.Setenv(ENV_SECRET_KEY, "")
+
+	creds, err := NewDefaultCredentialsChain("").Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "default-key", creds.AccessKey)
+
+	t.Setenv(ENV_ACCESS_KEY, "env-key")
+	t.Setenv(ENV_SECRET_KEY, "env-secret")
+
+	creds, err = NewDefaultCredentialsChain("").Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", creds.AccessKey)
+	assert.Equal(t, "env-secret", creds.SecretKey)
+
+	chain := NewChainCredentialsProvider(NewStaticCredentialsProvider("", ""), &ProfileCredentialsProvider{Profile: "missing"})
+	_, err = chain.Retrieve()
+	assert.ErrorIs(t, err, mega_err.ErrNoCredentials)
+}
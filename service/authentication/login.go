@@ -0,0 +1,173 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// Login logs in with a Megaport Portal username and password, for accounts that do not use API keys. oneTimePassword
+// is required for accounts with MFA enabled, and may be empty otherwise; see LoginMFA. It returns the session token,
+// which is also stored in the Config for use by every service.
+//
+// Deprecated: username and password logins are being retired in favour of API keys. Use LoginOauth.
+func (auth *Authentication) Login(username, password, oneTimePassword string) (string, error) {
+	return auth.LoginWithContext(context.Background(), username, password, oneTimePassword)
+}
+
+// LoginWithContext is the same as Login, using the supplied context for the login request.
+//
+// Deprecated: username and password logins are being retired in favour of API keys. Use LoginOauthWithContext.
+func (auth *Authentication) LoginWithContext(ctx context.Context, username, password, oneTimePassword string) (string, error) {
+	auth.Log.Debugln("Creating Session for:", username)
+
+	data := url.Values{}
+	data.Set("username", username)
+	data.Set("password", password)
+	if oneTimePassword != "" {
+		data.Set("oneTimePassword", oneTimePassword)
+	}
+
+	req, reqErr := http.NewRequestWithContext(ctx, "POST", auth.Endpoint+"/v2/login", strings.NewReader(data.Encode()))
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if auth.UserAgent != "" {
+		req.Header.Set("User-Agent", auth.UserAgent)
+	}
+
+	resp, resErr := auth.Client.Do(req)
+	isResErr, compiledResErr := auth.IsErrorResponse(resp, &resErr, 200)
+	if isResErr {
+		return "", compiledResErr
+	}
+	defer resp.Body.Close()
+
+	body, fileErr := io.ReadAll(resp.Body)
+	if fileErr != nil {
+		return "", fileErr
+	}
+
+	loginResponse := types.LoginResponse{}
+	if parseErr := json.Unmarshal(body, &loginResponse); parseErr != nil {
+		return "", parseErr
+	}
+
+	token := loginResponse.Data.OAuthToken.AccessToken
+	if token == "" {
+		token = loginResponse.Data.Session
+	}
+
+	if token == "" {
+		return "", errors.New("authentication error: login response did not include a session token")
+	}
+
+	auth.mu.Lock()
+	auth.permissions = loginResponse.Data.Permissions
+	auth.mu.Unlock()
+
+	auth.SetSessionToken(token)
+
+	auth.Log.Debugln("session established")
+	return token, nil
+}
+
+// LoginMFA logs in with a username and password, generating the one-time password from otpKey, the key shown when
+// adding an authenticator in the Megaport Portal. It returns mega_err.ErrNoOTPKeyDefined if otpKey is empty.
+//
+// Deprecated: username and password logins are being retired in favour of API keys. Use LoginOauth.
+func (auth *Authentication) LoginMFA(username, password, otpKey string) (string, error) {
+	return auth.LoginMFAWithContext(context.Background(), username, password, otpKey)
+}
+
+// LoginMFAWithContext is the same as LoginMFA, using the supplied context for the login request.
+//
+// Deprecated: username and password logins are being retired in favour of API keys. Use LoginOauthWithContext.
+func (auth *Authentication) LoginMFAWithContext(ctx context.Context, username, password, otpKey string) (string, error) {
+	oneTimePassword, err := GenerateTOTP(otpKey, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return auth.LoginWithContext(ctx, username, password, oneTimePassword)
+}
+
+// Permissions returns the permissions granted to the user by the last username and password login.
+func (auth *Authentication) Permissions() types.Permissions {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	return auth.permissions
+}
+
+// Logout ends the session created by Login, then checks that the session token is no longer accepted. It returns
+// mega_err.ErrSessionTokenStillExist if it is, and the API error if the check fails for any reason other than the
+// session being gone (401, 403 or 404). The session token is only cleared once the session is confirmed to be gone.
+func (auth *Authentication) Logout() error {
+	return auth.LogoutWithContext(context.Background())
+}
+
+// LogoutWithContext is the same as Logout, using the supplied context for its requests.
+func (auth *Authentication) LogoutWithContext(ctx context.Context) error {
+	token := auth.GetSessionToken()
+	if token == "" {
+		return nil
+	}
+
+	logoutResponse, err := auth.MakeAPICallWithContext(ctx, "GET", "/v2/logout/"+token, nil)
+	isResErr, compiledResErr := auth.IsErrorResponse(logoutResponse, &err, 200)
+	if isResErr {
+		return compiledResErr
+	}
+	logoutResponse.Body.Close()
+
+	// Logging in with the old token must now fail.
+	sessionResponse, err := auth.MakeAPICallWithContext(ctx, "GET", "/v2/login/"+token, nil)
+	if err != nil {
+		return err
+	}
+	defer sessionResponse.Body.Close()
+
+	switch sessionResponse.StatusCode {
+	case http.StatusOK:
+		return mega_err.ErrSessionTokenStillExist
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		// The session is gone.
+	default:
+		_, compiledResErr := auth.IsErrorResponse(sessionResponse, &err, http.StatusOK)
+		return compiledResErr
+	}
+
+	auth.mu.Lock()
+	auth.permissions = nil
+	auth.mu.Unlock()
+
+	auth.SetSessionToken("")
+	auth.Log.Debugln("session ended")
+	return nil
+}
@@ -0,0 +1,195 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+// The SHA-1 test vectors from RFC 6238 appendix B, using the ASCII key "12345678901234567890".
+func TestTOTP(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+
+	for unix, expected := range vectors {
+		assert.Equal(t, expected, totp(key, time.Unix(unix, 0), 8), "time %d", unix)
+	}
+}
+
+func TestGenerateTOTP(t *testing.T) {
+	// base32 of "12345678901234567890", written as an authenticator app displays it.
+	code, err := GenerateTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	_, err = GenerateTOTP("", time.Now())
+	assert.ErrorIs(t, err, mega_err.ErrNoOTPKeyDefined)
+
+	_, err = GenerateTOTP("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+// newLegacyAPI serves the username and password login endpoints, accepting "user"/"pass" with the current one-time
+// password for the RFC 6238 test key.
+func newLegacyAPI(logoutWorks bool) (*httptest.Server, *Authentication) {
+	sessions := map[string]bool{}
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/v2/login"):
+			r.ParseForm()
+			// Accept the previous one-time password too, in case the time step changed since it was generated.
+			current, _ := GenerateTOTP("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", time.Now())
+			previous, _ := GenerateTOTP("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", time.Now().Add(-TOTP_PERIOD))
+			otp := r.Form.Get("oneTimePassword")
+			if r.Form.Get("username") != "user" || r.Form.Get("password") != "pass" || (otp != current && otp != previous) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Invalid credentials","terms":"","data":""}`))
+				return
+			}
+			sessions["session-1"] = true
+			w.Write([]byte(`{"message":"ok","terms":"","data":{"session":"session-1","permissions":{"company-1":["read","order"]}}}`))
+		case strings.Contains(r.URL.Path, "/v2/logout/"):
+			if logoutWorks {
+				delete(sessions, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			}
+			w.Write([]byte(`{"message":"ok","terms":"","data":""}`))
+		case strings.Contains(r.URL.Path, "/v2/login/"):
+			if !sessions[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]] {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"Session expired","terms":"","data":""}`))
+				return
+			}
+			w.Write([]byte(`{"message":"ok","terms":"","data":{}}`))
+		case strings.HasSuffix(r.URL.Path, "/v2/products"):
+			w.Write([]byte(`{"message":"ok","terms":"","data":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+
+	return server, New(&config.Config{Log: logger, Endpoint: server.URL})
+}
+
+func TestLoginMFA(t *testing.T) {
+	server, auth := newLegacyAPI(true)
+	defer server.Close()
+
+	_, err := auth.LoginMFA("user", "wrong", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	assert.True(t, mega_err.IsUnauthorized(err))
+
+	_, err = auth.LoginMFA("user", "pass", "")
+	assert.ErrorIs(t, err, mega_err.ErrNoOTPKeyDefined)
+
+	token, err := auth.LoginMFA("user", "pass", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	assert.NoError(t, err)
+	assert.Equal(t, "session-1", token)
+	assert.Equal(t, "session-1", auth.GetSessionToken())
+	assert.True(t, auth.Permissions().Has("order"))
+	assert.False(t, auth.Permissions().Has("admin"))
+
+	assert.NoError(t, auth.Logout())
+	assert.Empty(t, auth.GetSessionToken())
+	assert.Nil(t, auth.Permissions())
+}
+
+func TestLogoutSessionStillExists(t *testing.T) {
+	server, auth := newLegacyAPI(false)
+	defer server.Close()
+
+	_, err := auth.LoginMFA("user", "pass", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, auth.Logout(), mega_err.ErrSessionTokenStillExist)
+	assert.Equal(t, "session-1", auth.GetSessionToken())
+}
+
+func TestLogoutSessionCheckFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/v2/login/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"Internal server error","terms":"","data":""}`))
+			return
+		}
+		w.Write([]byte(`{"message":"ok","terms":"","data":""}`))
+	}))
+	defer server.Close()
+
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+	auth := New(&config.Config{Log: logger, Endpoint: server.URL})
+	auth.SetSessionToken("session-1")
+
+	err := auth.Logout()
+	apiErr, ok := mega_err.AsAPIError(err)
+	if assert.True(t, ok, "expected an *APIError, got %v", err) {
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	}
+	assert.Equal(t, "session-1", auth.GetSessionToken())
+}
+
+// Run with -race: Login and Logout change the session token while other goroutines make API calls with it.
+func TestLoginLogoutDuringAPICalls(t *testing.T) {
+	server, auth := newLegacyAPI(true)
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				response, err := auth.MakeAPICall("GET", "/v2/products", nil)
+				if assert.NoError(t, err) {
+					response.Body.Close()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err := auth.LoginMFA("user", "pass", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+		assert.NoError(t, err)
+		assert.NoError(t, auth.Logout())
+	}
+	wg.Wait()
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// Timings used by FileTokenStore when locking.
+const (
+	// tokenLockPollInterval is how often a held lock is checked.
+	tokenLockPollInterval = 50 * time.Millisecond
+
+	// tokenLockStaleAfter is how old a lock file must be to be treated as abandoned by a crashed process.
+	tokenLockStaleAfter = 30 * time.Second
+)
+
+// CachedToken is a bearer token and the time it expires.
+type CachedToken struct {
+	AccessToken string    `json:"access_token"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// NewCachedToken returns the CachedToken for a token response received at the given time.
+func NewCachedToken(resp types.AccessTokenResponse, received time.Time) CachedToken {
+	return CachedToken{
+		AccessToken: resp.AccessToken,
+		Expiry:      received.Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}
+}
+
+// ValidFor reports whether the token is still valid after d.
+func (t CachedToken) ValidFor(d time.Duration) bool {
+	return t.AccessToken != "" && time.Now().Add(d).Before(t.Expiry)
+}
+
+// TokenStore persists bearer tokens so that they can be reused until they expire, e.g. by later runs of a command
+// line tool. Tokens are stored under a key identifying the credentials and environment; see TokenStoreKey.
+type TokenStore interface {
+	// Load returns the token stored under key. It returns false if there is none.
+	Load(key string) (CachedToken, bool, error)
+
+	// Save stores a token under key, replacing any token already stored.
+	Save(key string, token CachedToken) error
+
+	// Delete removes the token stored under key, if any.
+	Delete(key string) error
+}
+
+// TokenLocker is implemented by TokenStores that can be locked across processes. Authentication holds the lock
+// while it checks the store and requests a new token, so that processes starting together share a single login.
+type TokenLocker interface {
+	// Lock acquires the lock for key, waiting until it is available or ctx is done. The returned function releases
+	// it.
+	Lock(ctx context.Context, key string) (unlock func(), err error)
+}
+
+// TokenStoreKey returns the key for tokens issued to accessKey by the OAuth endpoint at tokenURL. It is a hash, so
+// that the access key is not written to the store in the clear.
+func TokenStoreKey(accessKey, tokenURL string) string {
+	sum := sha256.Sum256([]byte(accessKey + "\x00" + tokenURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// FileTokenStore stores each token in its own file, readable only by the current user. Files are replaced
+// atomically, and FileTokenStore implements TokenLocker using lock files, so a store may be shared by concurrent
+// processes.
+type FileTokenStore struct {
+	Dir string
+}
+
+// NewFileTokenStore returns a FileTokenStore storing tokens in dir. If dir is empty, the megaport/tokens directory of
+// the user's cache directory is used.
+func NewFileTokenStore(dir string) (*FileTokenStore, error) {
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(cacheDir, "megaport", "tokens")
+	}
+
+	return &FileTokenStore{Dir: dir}, nil
+}
+
+func (s *FileTokenStore) Load(key string) (CachedToken, bool, error) {
+	token := CachedToken{}
+
+	contents, err := os.ReadFile(s.path(key, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return token, false, nil
+	} else if err != nil {
+		return token, false, err
+	}
+
+	if err := json.Unmarshal(contents, &token); err != nil {
+		return token, false, err
+	}
+
+	return token, true, nil
+}
+
+func (s *FileTokenStore) Save(key string, token CachedToken) error {
+	contents, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it into place, so that readers never see a partial token.
+	file, err := os.CreateTemp(s.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), s.path(key, ".json"))
+}
+
+func (s *FileTokenStore) Delete(key string) error {
+	err := os.Remove(s.path(key, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Lock acquires the lock for key by creating a lock file, waiting while another process holds it. Lock files older
+// than 30 seconds are assumed to have been left by a process that died and are removed.
+func (s *FileTokenStore) Lock(ctx context.Context, key string) (func(), error) {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return nil, err
+	}
+
+	lockPath := s.path(key, ".lock")
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > tokenLockStaleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+
+		select {
+		case <-time.After(tokenLockPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *FileTokenStore) path(key, ext string) string {
+	return filepath.Join(s.Dir, key+ext)
+}
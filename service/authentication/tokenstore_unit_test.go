@@ -0,0 +1,136 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	store := &FileTokenStore{Dir: filepath.Join(t.TempDir(), "tokens")}
+	key := TokenStoreKey("access", "https://auth-m2m.megaport.com/oauth2/token")
+
+	_, ok, err := store.Load(key)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	token := CachedToken{AccessToken: "token-1", Expiry: time.Now().Add(time.Hour).Round(0)}
+	assert.NoError(t, store.Save(key, token))
+
+	loaded, ok, err := store.Load(key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, token.AccessToken, loaded.AccessToken)
+	assert.True(t, token.Expiry.Equal(loaded.Expiry))
+
+	info, err := os.Stat(filepath.Join(store.Dir, key+".json"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(store.Dir)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	assert.NoError(t, store.Delete(key))
+	assert.NoError(t, store.Delete(key))
+
+	_, ok, err = store.Load(key)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestTokenStoreKey(t *testing.T) {
+	production := TokenStoreKey("access", "https://auth-m2m.megaport.com/oauth2/token")
+	staging := TokenStoreKey("access", "https://oauth-m2m-staging.megaport.com/oauth2/token")
+
+	assert.NotEqual(t, production, staging)
+	assert.NotEqual(t, production, TokenStoreKey("other", "https://auth-m2m.megaport.com/oauth2/token"))
+	assert.NotContains(t, production, "access")
+}
+
+func TestFileTokenStoreLock(t *testing.T) {
+	store := &FileTokenStore{Dir: t.TempDir()}
+
+	unlock, err := store.Lock(context.Background(), "key")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = store.Lock(ctx, "key")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	unlock()
+
+	unlock, err = store.Lock(context.Background(), "key")
+	assert.NoError(t, err)
+	unlock()
+
+	// A lock left behind by a process that died is eventually taken over.
+	lockPath := filepath.Join(store.Dir, "stale.lock")
+	assert.NoError(t, os.WriteFile(lockPath, nil, 0600))
+	stale := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(lockPath, stale, stale))
+
+	unlock, err = store.Lock(context.Background(), "stale")
+	assert.NoError(t, err)
+	unlock()
+}
+
+func TestTokenStoreSharedBetweenAuthentications(t *testing.T) {
+	api := newMockAPI(3600)
+	api.tokenDelay = 20 * time.Millisecond
+	defer api.server.Close()
+
+	store := &FileTokenStore{Dir: t.TempDir()}
+
+	// Each Authentication stands in for a separate process sharing the store.
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			auth := api.newAuth()
+			auth.TokenStore = store
+
+			token, err := auth.LoginOauthWithContext(context.Background(), "access", "secret")
+			assert.NoError(t, err)
+			assert.Equal(t, "token-1", token)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&api.issued))
+
+	// Once the API rejects the cached token it is removed from the store.
+	auth := api.newAuth()
+	auth.TokenStore = store
+	auth.InvalidateToken("token-1")
+
+	token, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&api.issued))
+}
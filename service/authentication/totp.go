@@ -0,0 +1,81 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+)
+
+// TOTP parameters used by the Megaport Portal's authenticator setup.
+const (
+	TOTP_DIGITS = 6
+	TOTP_PERIOD = 30 * time.Second
+)
+
+// GenerateTOTP returns the RFC 6238 time-based one-time password for the given time, using the base32 key shown
+// when adding an authenticator in the Megaport Portal. Spaces and letter case in the key are ignored.
+func GenerateTOTP(otpKey string, at time.Time) (string, error) {
+	if otpKey == "" {
+		return "", mega_err.ErrNoOTPKeyDefined
+	}
+
+	key, err := decodeOTPKey(otpKey)
+	if err != nil {
+		return "", err
+	}
+
+	return totp(key, at, TOTP_DIGITS), nil
+}
+
+func decodeOTPKey(otpKey string) ([]byte, error) {
+	normalised := strings.ToUpper(strings.ReplaceAll(otpKey, " ", ""))
+	normalised = strings.TrimRight(normalised, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalised)
+	if err != nil {
+		return nil, fmt.Errorf("invalid one time password key: %w", err)
+	}
+
+	return key, nil
+}
+
+// totp computes the HMAC-SHA1 one-time password with the given number of digits for the time step containing at.
+func totp(key []byte, at time.Time, digits int) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/int64(TOTP_PERIOD/time.Second)))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, RFC 4226 section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulus := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulus *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, code%modulus)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/megaport/megaportgo/config"
+)
+
+// TokenSource supplies bearer tokens to a Transport. *Authentication is a TokenSource.
+type TokenSource interface {
+	// Token returns a valid bearer token.
+	Token(ctx context.Context) (string, error)
+
+	// InvalidateToken discards token after the API has rejected it.
+	InvalidateToken(token string)
+}
+
+// Transport is an http.RoundTripper that authenticates each request with a bearer token from its Source. If the API
+// responds with 401 Unauthorized, the token is refreshed and the request is sent once more.
+//
+// Requests that already carry credentials other than a bearer token, such as the Basic authentication used to
+// request a token, are passed through unchanged.
+type Transport struct {
+	Source TokenSource
+
+	// Base is the RoundTripper used to make requests. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+}
+
+// NewTransport returns a Transport authenticating requests with tokens from source.
+func NewTransport(source TokenSource, base http.RoundTripper) *Transport {
+	return &Transport{Source: source, Base: base}
+}
+
+// NewHttpClient returns an HTTP client that authenticates requests with tokens from auth, refreshing them as they
+// expire.
+func (auth *Authentication) NewHttpClient() *http.Client {
+	base := config.NewHttpClient()
+	base.Transport = NewTransport(auth, base.Transport)
+	return base
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authorization := req.Header.Get("Authorization")
+	if authorization != "" && !strings.HasPrefix(authorization, "Bearer ") {
+		return t.base().RoundTrip(req)
+	}
+
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	// Send a copy so that the original request can be sent again with a new token.
+	response, err := t.base().RoundTrip(authorize(req, token))
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		return response, nil
+	}
+
+	t.Source.InvalidateToken(token)
+	newToken, err := t.Source.Token(req.Context())
+	if err != nil {
+		return response, nil
+	}
+
+	retry := authorize(req, newToken)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return response, nil
+		}
+		retry.Body = body
+	}
+
+	io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+
+	return t.base().RoundTrip(retry)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// authorize returns a copy of req carrying the bearer token, as a RoundTripper must not modify its request.
+func authorize(req *http.Request, token string) *http.Request {
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+	return authorized
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,219 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_ENDPOINT = "https://api-staging.megaport.com/"
+
+// rewriteTransport sends every request to the test server, whatever its original host.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (r rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = r.target.Scheme
+	rewritten.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(rewritten)
+}
+
+// mockAPI issues tokens "token-1", "token-2", ... and accepts only the most recently issued token.
+type mockAPI struct {
+	server     *httptest.Server
+	issued     int32
+	expiresIn  int
+	tokenDelay time.Duration
+	bodies     []string
+	mu         sync.Mutex
+}
+
+func newMockAPI(expiresIn int) *mockAPI {
+	m := &mockAPI{expiresIn: expiresIn}
+	m.server = httptest.NewServer(http.HandlerFunc(m.handle))
+	return m
+}
+
+func (m *mockAPI) handle(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/oauth2/token") {
+		time.Sleep(m.tokenDelay)
+		issued := atomic.AddInt32(&m.issued, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, issued, m.expiresIn)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	m.mu.Lock()
+	m.bodies = append(m.bodies, string(body))
+	m.mu.Unlock()
+
+	current := fmt.Sprintf("Bearer token-%d", atomic.LoadInt32(&m.issued))
+	if r.Header.Get("Authorization") != current {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Unauthorized","terms":"","data":""}`))
+		return
+	}
+
+	w.Write([]byte(`{"message":"ok","terms":"","data":[]}`))
+}
+
+func (m *mockAPI) newAuth() *Authentication {
+	target, _ := url.Parse(m.server.URL)
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+
+	auth := New(&config.Config{
+		Log:      logger,
+		Endpoint: TEST_ENDPOINT,
+		Client:   &http.Client{Transport: rewriteTransport{target}},
+	})
+	auth.SetCredentials("access", "secret")
+	return auth
+}
+
+func TestTokenSingleFlight(t *testing.T) {
+	api := newMockAPI(3600)
+	api.tokenDelay = 20 * time.Millisecond
+	defer api.server.Close()
+
+	auth := api.newAuth()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := auth.Token(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "token-1", token)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&api.issued))
+}
+
+func TestTokenRefreshBeforeExpiry(t *testing.T) {
+	api := newMockAPI(30)
+	defer api.server.Close()
+
+	auth := api.newAuth()
+
+	first, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+
+	// The token expires within the default refresh window, so it is refreshed.
+	second, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	auth.RefreshWindow = 0
+	third, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, second, third)
+}
+
+func TestTransportRefreshesOnUnauthorized(t *testing.T) {
+	api := newMockAPI(3600)
+	defer api.server.Close()
+
+	auth := api.newAuth()
+	target, _ := url.Parse(api.server.URL)
+	cfg := auth.Config
+	cfg.Client = &http.Client{Transport: NewTransport(auth, rewriteTransport{target})}
+
+	_, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+
+	// Another process has since been issued a token, revoking the cached one.
+	atomic.AddInt32(&api.issued, 1)
+
+	response, err := cfg.MakeAPICall("POST", "/v2/products", []byte(`{"retry":true}`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{`{"retry":true}`, `{"retry":true}`}, api.bodies)
+}
+
+func TestLoginOauthCredentialsChange(t *testing.T) {
+	api := newMockAPI(3600)
+	defer api.server.Close()
+
+	auth := api.newAuth()
+
+	token, err := auth.LoginOauth("access", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The same credentials reuse the cached token.
+	token, err = auth.LoginOauth("access", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	token, err = auth.LoginOauth("other-access", "other-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, "token-2", auth.GetSessionToken())
+}
+
+// Run with -race: LoginOauth sets the session token while other goroutines make API calls with it.
+func TestLoginOauthDuringAPICalls(t *testing.T) {
+	api := newMockAPI(3600)
+	defer api.server.Close()
+
+	auth := api.newAuth()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				response, err := auth.MakeAPICall("GET", "/v2/products", nil)
+				if assert.NoError(t, err) {
+					response.Body.Close()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		auth.InvalidateToken(auth.GetSessionToken())
+		_, err := auth.LoginOauth("access", "secret")
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,225 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProductRef identifies a product in a snapshot diff.
+type ProductRef struct {
+	UID  string
+	Name string
+	Type string
+}
+
+// FieldChange is a field whose value differs between two snapshots of a product. Before and After hold the values as
+// decoded from JSON, and are nil if the field is missing from that snapshot.
+type FieldChange struct {
+	// Field is the path of the field in the snapshot's JSON, e.g. "resources.interface[0].up".
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// ProductDiff lists the fields of a product that changed between two snapshots.
+type ProductDiff struct {
+	ProductRef
+	Fields []FieldChange
+}
+
+// SnapshotDiff reports the products added, removed and changed between two snapshots, each sorted by product UID.
+type SnapshotDiff struct {
+	Added   []ProductRef
+	Removed []ProductRef
+	Changed []ProductDiff
+}
+
+// Empty reports whether the snapshots hold the same products in the same state.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String returns the diff as a report with one line for each added or removed product and each changed field.
+func (d SnapshotDiff) String() string {
+	var b strings.Builder
+	for _, ref := range d.Added {
+		fmt.Fprintf(&b, "+ %s %s %q\n", ref.Type, ref.UID, ref.Name)
+	}
+	for _, ref := range d.Removed {
+		fmt.Fprintf(&b, "- %s %s %q\n", ref.Type, ref.UID, ref.Name)
+	}
+	for _, product := range d.Changed {
+		fmt.Fprintf(&b, "~ %s %s %q\n", product.Type, product.UID, product.Name)
+		for _, field := range product.Fields {
+			fmt.Fprintf(&b, "    %s: %v -> %v\n", field.Field, field.Before, field.After)
+		}
+	}
+	return b.String()
+}
+
+// snapshotEntry is a product of a snapshot, decoded from JSON for comparison.
+type snapshotEntry struct {
+	ref    ProductRef
+	fields map[string]interface{}
+}
+
+// Diff compares two snapshots field by field. The VXCs of Ports, MCRs and MVEs are compared as products in their own
+// right, not as fields of the product they are attached to. Lists, such as prefix filter lists, are compared item by
+// item in order.
+func Diff(before *Snapshot, after *Snapshot) (SnapshotDiff, error) {
+	diff := SnapshotDiff{}
+
+	beforeEntries, err := snapshotEntries(before)
+	if err != nil {
+		return diff, err
+	}
+	afterEntries, err := snapshotEntries(after)
+	if err != nil {
+		return diff, err
+	}
+
+	for _, uid := range sortedUIDs(afterEntries) {
+		afterEntry := afterEntries[uid]
+		beforeEntry, ok := beforeEntries[uid]
+		if !ok {
+			diff.Added = append(diff.Added, afterEntry.ref)
+			continue
+		}
+
+		var changes []FieldChange
+		compareFields("", beforeEntry.fields, afterEntry.fields, &changes)
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, ProductDiff{ProductRef: afterEntry.ref, Fields: changes})
+		}
+	}
+
+	for _, uid := range sortedUIDs(beforeEntries) {
+		if _, ok := afterEntries[uid]; !ok {
+			diff.Removed = append(diff.Removed, beforeEntries[uid].ref)
+		}
+	}
+
+	return diff, nil
+}
+
+func snapshotEntries(snapshot *Snapshot) (map[string]snapshotEntry, error) {
+	entries := map[string]snapshotEntry{}
+
+	add := func(ref ProductRef, product interface{}) error {
+		data, err := json.Marshal(product)
+		if err != nil {
+			return err
+		}
+
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		delete(fields, "associatedVxcs")
+
+		entries[ref.UID] = snapshotEntry{ref: ref, fields: fields}
+		return nil
+	}
+
+	for _, port := range snapshot.Ports {
+		if err := add(ProductRef{UID: port.UID, Name: port.Name, Type: port.Type}, port); err != nil {
+			return nil, err
+		}
+	}
+	for _, mcr := range snapshot.MCRs {
+		if err := add(ProductRef{UID: mcr.UID, Name: mcr.Name, Type: mcr.Type}, mcr); err != nil {
+			return nil, err
+		}
+	}
+	for _, mve := range snapshot.MVEs {
+		if err := add(ProductRef{UID: mve.UID, Name: mve.Name, Type: mve.Type}, mve); err != nil {
+			return nil, err
+		}
+	}
+	for _, vxc := range snapshot.VXCs {
+		if err := add(ProductRef{UID: vxc.UID, Name: vxc.Name, Type: vxc.Type}, vxc); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+func sortedUIDs(entries map[string]snapshotEntry) []string {
+	uids := make([]string, 0, len(entries))
+	for uid := range entries {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// compareFields appends the differences between two JSON values to changes, descending into objects and arrays.
+func compareFields(path string, before interface{}, after interface{}, changes *[]FieldChange) {
+	beforeObject, beforeIsObject := before.(map[string]interface{})
+	afterObject, afterIsObject := after.(map[string]interface{})
+	if beforeIsObject && afterIsObject {
+		keys := map[string]bool{}
+		for key := range beforeObject {
+			keys[key] = true
+		}
+		for key := range afterObject {
+			keys[key] = true
+		}
+
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			compareFields(join(path, key), beforeObject[key], afterObject[key], changes)
+		}
+		return
+	}
+
+	beforeArray, beforeIsArray := before.([]interface{})
+	afterArray, afterIsArray := after.([]interface{})
+	if beforeIsArray && afterIsArray {
+		for i := 0; i < len(beforeArray) || i < len(afterArray); i++ {
+			var beforeItem, afterItem interface{}
+			if i < len(beforeArray) {
+				beforeItem = beforeArray[i]
+			}
+			if i < len(afterArray) {
+				afterItem = afterArray[i]
+			}
+			compareFields(fmt.Sprintf("%s[%d]", path, i), beforeItem, afterItem, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, FieldChange{Field: path, Before: before, After: after})
+	}
+}
+
+func join(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// the `inventory` package captures the state of every product in an account as a versioned JSON snapshot, and
+// reports the differences between two snapshots, e.g. before and after maintenance.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/service/mcr"
+	"github.com/megaport/megaportgo/service/mve"
+	"github.com/megaport/megaportgo/service/product"
+	"github.com/megaport/megaportgo/service/vxc"
+	"github.com/megaport/megaportgo/types"
+)
+
+// SNAPSHOT_VERSION is the version of the snapshot document written by this package.
+const SNAPSHOT_VERSION = 1
+
+type Inventory struct {
+	*config.Config
+	product *product.Product
+	mcr     *mcr.MCR
+	mve     *mve.MVE
+	vxc     *vxc.VXC
+}
+
+func New(cfg *config.Config) *Inventory {
+	return &Inventory{
+		Config:  cfg,
+		product: product.New(cfg),
+		mcr:     mcr.New(cfg),
+		mve:     mve.New(cfg),
+		vxc:     vxc.New(cfg),
+	}
+}
+
+// Snapshot is the state of the active products of an account at a point in time. Each list is sorted by product UID.
+type Snapshot struct {
+	Version int             `json:"version"`
+	TakenAt types.Timestamp `json:"takenAt"`
+
+	Ports []types.Port  `json:"ports"`
+	MCRs  []MCRSnapshot `json:"mcrs"`
+	MVEs  []types.MVE   `json:"mves"`
+
+	// VXCs holds the details of each VXC, including its CSP connections in Resources.CspConnection.
+	VXCs []types.VXC `json:"vxcs"`
+}
+
+// MCRSnapshot is the state of an MCR together with its prefix filter lists.
+type MCRSnapshot struct {
+	types.MCR
+	PrefixFilterLists []types.PrefixFilterList `json:"prefixFilterLists"`
+}
+
+// ReadSnapshot decodes a snapshot written by this or an earlier version of the package. It returns
+// mega_err.ErrUnsupportedSnapshotVersion for snapshots of other versions.
+func ReadSnapshot(data []byte) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+
+	if snapshot.Version < 1 || snapshot.Version > SNAPSHOT_VERSION {
+		return nil, fmt.Errorf("%w: %d", mega_err.ErrUnsupportedSnapshotVersion, snapshot.Version)
+	}
+
+	return snapshot, nil
+}
+
+// Snapshot captures the active Ports, MCRs, MVEs and VXCs of the account, fetching the full details of each MCR, MVE
+// and VXC, e.g.
+//
+//	before, err := client.Inventory.Snapshot()
+//	...
+//	after, err := client.Inventory.Snapshot()
+//	diff := inventory.Diff(before, after)
+//
+// It fails if any product's details cannot be fetched, rather than returning an incomplete snapshot. Snapshots can be
+// stored with json.Marshal and read back with ReadSnapshot.
+func (i *Inventory) Snapshot() (*Snapshot, error) {
+	return i.SnapshotWithContext(context.Background())
+}
+
+// SnapshotWithContext is the same as Snapshot, using the supplied context for the API calls.
+func (i *Inventory) SnapshotWithContext(ctx context.Context) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version: SNAPSHOT_VERSION,
+		TakenAt: types.NewTimestamp(time.Now()),
+		Ports:   []types.Port{},
+		MCRs:    []MCRSnapshot{},
+		MVEs:    []types.MVE{},
+		VXCs:    []types.VXC{},
+	}
+
+	// The products are listed once, so that every part of the snapshot comes from the same listing.
+	products, err := i.product.QueryProductsWithContext(ctx, product.Query().Active())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, port := range products.Ports() {
+		snapshot.Ports = append(snapshot.Ports, *port)
+	}
+
+	for _, mcr := range products.MCRs() {
+		details, err := i.mcr.GetMCRDetailsWithContext(ctx, mcr.UID)
+		if err != nil {
+			return nil, err
+		}
+
+		prefixFilterLists, err := i.product.GetMCRPrefixFilterListsWithContext(ctx, mcr.UID)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.MCRs = append(snapshot.MCRs, MCRSnapshot{MCR: details, PrefixFilterLists: prefixFilterLists})
+	}
+
+	for _, mve := range products.MVEs() {
+		details, err := i.mve.GetMVEDetailsWithContext(ctx, mve.UID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.MVEs = append(snapshot.MVEs, *details)
+	}
+
+	for _, vxc := range products.VXCs() {
+		details, err := i.vxc.GetVXCDetailsWithContext(ctx, vxc.UID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.VXCs = append(snapshot.VXCs, details)
+	}
+
+	sort.Slice(snapshot.Ports, func(a, b int) bool { return snapshot.Ports[a].UID < snapshot.Ports[b].UID })
+	sort.Slice(snapshot.MCRs, func(a, b int) bool { return snapshot.MCRs[a].UID < snapshot.MCRs[b].UID })
+	sort.Slice(snapshot.MVEs, func(a, b int) bool { return snapshot.MVEs[a].UID < snapshot.MVEs[b].UID })
+	sort.Slice(snapshot.VXCs, func(a, b int) bool { return snapshot.VXCs[a].UID < snapshot.VXCs[b].UID })
+
+	return snapshot, nil
+}
@@ -0,0 +1,208 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_PRODUCTS_RESPONSE = `{"message":"ok","terms":"","data":[
+	{"productUid":"port-2","productName":"Port Two","productType":"MEGAPORT","provisioningStatus":"LIVE"},
+	{"productUid":"port-1","productName":"Port One","productType":"MEGAPORT","provisioningStatus":"LIVE",
+		"associatedVxcs":[{"productUid":"vxc-1","productName":"VXC","productType":"VXC","provisioningStatus":"LIVE"}]},
+	{"productUid":"port-3","productName":"Old Port","productType":"MEGAPORT","provisioningStatus":"DECOMMISSIONED"},
+	{"productUid":"mcr-1","productName":"MCR","productType":"MCR2","provisioningStatus":"LIVE",
+		"associatedVxcs":[{"productUid":"vxc-1","productName":"VXC","productType":"VXC","provisioningStatus":"LIVE"}]},
+	{"productUid":"mve-1","productName":"MVE","productType":"MVE","provisioningStatus":"CONFIGURED"}
+]}`
+
+var TEST_PRODUCT_DETAILS = map[string]string{
+	"/v2/product/mcr-1": `{"productUid":"mcr-1","productName":"MCR","productType":"MCR2","provisioningStatus":"LIVE",
+		"resources":{"virtual_router":{"mcrAsn":133937}}}`,
+	"/v2/product/mve-1": `{"productUid":"mve-1","productName":"MVE","productType":"MVE","provisioningStatus":"CONFIGURED",
+		"vendor":"CISCO"}`,
+	"/v2/product/vxc-1": `{"productUid":"vxc-1","productName":"VXC","productType":"VXC","provisioningStatus":"LIVE",
+		"rateLimit":500,"resources":{"csp_connection":{"connectType":"AWS","ownerAccount":"123456789012"}}}`,
+	"/v2/product/mcr2/mcr-1/prefixLists": `[{"id":1,"description":"Allowed","addressFamily":"IPv4"}]`,
+}
+
+func newTestInventory(handler http.HandlerFunc) (*httptest.Server, *Inventory) {
+	server := httptest.NewServer(handler)
+
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+
+	return server, New(&config.Config{Log: logger, Endpoint: server.URL, Client: server.Client()})
+}
+
+func TestSnapshot(t *testing.T) {
+	server, inventory := newTestInventory(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/products" {
+			w.Write([]byte(TEST_PRODUCTS_RESPONSE))
+			return
+		}
+		if details, ok := TEST_PRODUCT_DETAILS[r.URL.Path]; ok {
+			w.Write([]byte(`{"message":"ok","terms":"","data":` + details + `}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not found","terms":"","data":""}`))
+	})
+	defer server.Close()
+
+	snapshot, err := inventory.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, SNAPSHOT_VERSION, snapshot.Version)
+	assert.False(t, snapshot.TakenAt.IsZero())
+
+	if assert.Len(t, snapshot.Ports, 2) {
+		assert.Equal(t, "port-1", snapshot.Ports[0].UID)
+		assert.Equal(t, "port-2", snapshot.Ports[1].UID)
+	}
+	if assert.Len(t, snapshot.MCRs, 1) {
+		assert.Equal(t, 133937, snapshot.MCRs[0].Resources.VirtualRouter.ASN)
+		assert.Equal(t, []types.PrefixFilterList{{Id: 1, Description: "Allowed", AddressFamily: "IPv4"}}, snapshot.MCRs[0].PrefixFilterLists)
+	}
+	if assert.Len(t, snapshot.MVEs, 1) {
+		assert.Equal(t, "CISCO", snapshot.MVEs[0].Vendor)
+	}
+	if assert.Len(t, snapshot.VXCs, 1) {
+		assert.Equal(t, 500, snapshot.VXCs[0].RateLimit)
+		assert.Equal(t, "AWS", snapshot.VXCs[0].Resources.CspConnection.(map[string]interface{})["connectType"])
+	}
+
+	data, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"prefixFilterLists":[{"id":1`)
+
+	read, err := ReadSnapshot(data)
+	assert.NoError(t, err)
+	diff, err := Diff(snapshot, read)
+	assert.NoError(t, err)
+	assert.True(t, diff.Empty(), diff.String())
+}
+
+func TestSnapshotRequestsProductsOnce(t *testing.T) {
+	var listings int32
+	server, inventory := newTestInventory(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/products" {
+			atomic.AddInt32(&listings, 1)
+			w.Write([]byte(TEST_PRODUCTS_RESPONSE))
+			return
+		}
+		w.Write([]byte(`{"message":"ok","terms":"","data":` + TEST_PRODUCT_DETAILS[r.URL.Path] + `}`))
+	})
+	defer server.Close()
+
+	_, err := inventory.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&listings))
+}
+
+func TestSnapshotDetailsNotFound(t *testing.T) {
+	for _, missing := range []string{"/v2/product/mcr-1", "/v2/product/mve-1", "/v2/product/vxc-1"} {
+		t.Run(missing, func(t *testing.T) {
+			server, inventory := newTestInventory(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v2/products" {
+					w.Write([]byte(TEST_PRODUCTS_RESPONSE))
+					return
+				}
+				if details, ok := TEST_PRODUCT_DETAILS[r.URL.Path]; ok && r.URL.Path != missing {
+					w.Write([]byte(`{"message":"ok","terms":"","data":` + details + `}`))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"Not found","terms":"","data":""}`))
+			})
+			defer server.Close()
+
+			snapshot, err := inventory.Snapshot()
+			assert.True(t, mega_err.IsNotFound(err))
+			assert.Nil(t, snapshot)
+		})
+	}
+}
+
+func TestReadSnapshotVersion(t *testing.T) {
+	_, err := ReadSnapshot([]byte(`{"version":2,"ports":[]}`))
+	assert.ErrorIs(t, err, mega_err.ErrUnsupportedSnapshotVersion)
+
+	_, err = ReadSnapshot([]byte(`{"ports":[]}`))
+	assert.ErrorIs(t, err, mega_err.ErrUnsupportedSnapshotVersion)
+}
+
+func TestDiff(t *testing.T) {
+	before := &Snapshot{
+		Version: SNAPSHOT_VERSION,
+		Ports: []types.Port{
+			{UID: "port-1", Name: "Port", Type: "MEGAPORT", PortSpeed: 10000, AttributeTags: types.Tags{"owner": "networks"},
+				AssociatedVXCs: []types.VXC{{UID: "vxc-1", RateLimit: 100}}},
+			{UID: "port-2", Name: "Removed", Type: "MEGAPORT"},
+		},
+		MCRs: []MCRSnapshot{{MCR: types.MCR{UID: "mcr-1", Name: "MCR", Type: "MCR2"},
+			PrefixFilterLists: []types.PrefixFilterList{{Id: 1, Description: "Allowed", AddressFamily: "IPv4"}}}},
+		VXCs: []types.VXC{{UID: "vxc-1", Name: "VXC", Type: "VXC", RateLimit: 100}},
+	}
+	after := &Snapshot{
+		Version: SNAPSHOT_VERSION,
+		Ports: []types.Port{
+			{UID: "port-1", Name: "Port", Type: "MEGAPORT", PortSpeed: 10000, AttributeTags: types.Tags{"owner": "platform"},
+				AssociatedVXCs: []types.VXC{{UID: "vxc-1", RateLimit: 1000}}},
+		},
+		MCRs: []MCRSnapshot{{MCR: types.MCR{UID: "mcr-1", Name: "MCR", Type: "MCR2"},
+			PrefixFilterLists: []types.PrefixFilterList{{Id: 1, Description: "Allowed", AddressFamily: "IPv4"},
+				{Id: 2, Description: "Blocked", AddressFamily: "IPv6"}}}},
+		MVEs: []types.MVE{{UID: "mve-1", Name: "Added", Type: "MVE"}},
+		VXCs: []types.VXC{{UID: "vxc-1", Name: "VXC", Type: "VXC", RateLimit: 1000}},
+	}
+
+	diff, err := Diff(before, after)
+	assert.NoError(t, err)
+	assert.False(t, diff.Empty())
+
+	assert.Equal(t, []ProductRef{{UID: "mve-1", Name: "Added", Type: "MVE"}}, diff.Added)
+	assert.Equal(t, []ProductRef{{UID: "port-2", Name: "Removed", Type: "MEGAPORT"}}, diff.Removed)
+
+	if assert.Len(t, diff.Changed, 3) {
+		assert.Equal(t, "mcr-1", diff.Changed[0].UID)
+		assert.Equal(t, []FieldChange{{Field: "prefixFilterLists[1]", Before: nil,
+			After: map[string]interface{}{"id": float64(2), "description": "Blocked", "addressFamily": "IPv6"}}},
+			diff.Changed[0].Fields)
+
+		// The change to the VXC is reported for the VXC only, not for the Port it is attached to.
+		assert.Equal(t, "port-1", diff.Changed[1].UID)
+		assert.Equal(t, []FieldChange{{Field: "attributeTags.owner", Before: "networks", After: "platform"}},
+			diff.Changed[1].Fields)
+
+		assert.Equal(t, "vxc-1", diff.Changed[2].UID)
+		assert.Equal(t, []FieldChange{{Field: "rateLimit", Before: float64(100), After: float64(1000)}},
+			diff.Changed[2].Fields)
+	}
+
+	assert.Contains(t, diff.String(), "+ MVE mve-1 \"Added\"\n")
+	assert.Contains(t, diff.String(), "    rateLimit: 100 -> 1000\n")
+}
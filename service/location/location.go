@@ -21,8 +21,8 @@
 package location
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"io/ioutil"
 
@@ -47,7 +47,12 @@ func New(cfg *config.Config) *Location {
 // GetLocationByID looks up locations based on the IDs that are exposed by the API. These IDs can be found by querying
 // the API directly or iterating over GetAllLocations.
 func (l *Location) GetLocationByID(locationID int) (types.Location, error) {
-	allLocations, locErr := l.GetAllLocations()
+	return l.GetLocationByIDWithContext(context.Background(), locationID)
+}
+
+// GetLocationByIDWithContext is the same as GetLocationByID, using the supplied context for the API call.
+func (l *Location) GetLocationByIDWithContext(ctx context.Context, locationID int) (types.Location, error) {
+	allLocations, locErr := l.GetAllLocationsWithContext(ctx)
 
 	if locErr != nil {
 		return types.Location{}, locErr
@@ -59,13 +64,18 @@ func (l *Location) GetLocationByID(locationID int) (types.Location, error) {
 		}
 	}
 
-	return types.Location{}, errors.New(mega_err.ERR_LOCATION_NOT_FOUND)
+	return types.Location{}, mega_err.ErrLocationNotFound
 }
 
 // GetLocationByName is an exact name lookup for Megaport Locations. This is not fuzzy, if the exact Location name is
 // not passed in, you will not get a result. This is supposed to return a single entry.
 func (l *Location) GetLocationByName(locationName string) (types.Location, error) {
-	allLocations, locErr := l.GetAllLocations()
+	return l.GetLocationByNameWithContext(context.Background(), locationName)
+}
+
+// GetLocationByNameWithContext is the same as GetLocationByName, using the supplied context for the API call.
+func (l *Location) GetLocationByNameWithContext(ctx context.Context, locationName string) (types.Location, error) {
+	allLocations, locErr := l.GetAllLocationsWithContext(ctx)
 
 	if locErr != nil {
 		return types.Location{}, locErr
@@ -77,20 +87,25 @@ func (l *Location) GetLocationByName(locationName string) (types.Location, error
 		}
 	}
 
-	return types.Location{}, errors.New(mega_err.ERR_LOCATION_NOT_FOUND)
+	return types.Location{}, mega_err.ErrLocationNotFound
 }
 
 // GetAllLocations retrieves all Megaport locations from the API.
 func (l *Location) GetAllLocations() ([]types.Location, error) {
-	locationUrl := "/v2/locations"
-	response, resErr := l.Config.MakeAPICall("GET", locationUrl, nil)
-	defer response.Body.Close()
+	return l.GetAllLocationsWithContext(context.Background())
+}
 
+// GetAllLocationsWithContext retrieves all Megaport locations from the API, using the supplied context for the API
+// call.
+func (l *Location) GetAllLocationsWithContext(ctx context.Context) ([]types.Location, error) {
+	locationUrl := "/v2/locations"
+	response, resErr := l.Config.MakeAPICallWithContext(ctx, "GET", locationUrl, nil)
 	isResErr, compiledResError := l.Config.IsErrorResponse(response, &resErr, 200)
 
 	if isResErr {
 		return nil, compiledResError
 	}
+	defer response.Body.Close()
 
 	body, fileErr := io.ReadAll(response.Body)
 
@@ -110,7 +125,12 @@ func (l *Location) GetAllLocations() ([]types.Location, error) {
 }
 
 func (l *Location) GetLocationByNameFuzzy(search string) ([]types.Location, error) {
-	locations, _ := l.GetAllLocations()
+	return l.GetLocationByNameFuzzyWithContext(context.Background(), search)
+}
+
+// GetLocationByNameFuzzyWithContext is the same as GetLocationByNameFuzzy, using the supplied context for the API call.
+func (l *Location) GetLocationByNameFuzzyWithContext(ctx context.Context, search string) ([]types.Location, error) {
+	locations, _ := l.GetAllLocationsWithContext(ctx)
 	var matchedLocations []types.Location
 
 	for i := 0; i < len(locations); i++ {
@@ -122,21 +142,26 @@ func (l *Location) GetLocationByNameFuzzy(search string) ([]types.Location, erro
 	if len(matchedLocations) > 0 {
 		return matchedLocations, nil
 	} else {
-		return matchedLocations, errors.New(mega_err.ERR_NO_MATCHING_LOCATIONS)
+		return matchedLocations, mega_err.ErrNoMatchingLocations
 	}
 }
 
 func (l *Location) GetCountries() ([]types.Country, error) {
+	return l.GetCountriesWithContext(context.Background())
+}
+
+// GetCountriesWithContext is the same as GetCountries, using the supplied context for the API call.
+func (l *Location) GetCountriesWithContext(ctx context.Context) ([]types.Country, error) {
 	marketCodeUrl := "/v2/networkRegions"
-	response, resErr := l.Config.MakeAPICall("GET", marketCodeUrl, nil)
+	response, resErr := l.Config.MakeAPICallWithContext(ctx, "GET", marketCodeUrl, nil)
 	allCountries := make([]types.Country, 0)
-	defer response.Body.Close()
 
 	isResErr, compiledResError := l.Config.IsErrorResponse(response, &resErr, 200)
 
 	if isResErr {
 		return nil, compiledResError
 	}
+	defer response.Body.Close()
 
 	body, fileErr := ioutil.ReadAll(response.Body)
 
@@ -162,7 +187,12 @@ func (l *Location) GetCountries() ([]types.Country, error) {
 }
 
 func (l *Location) GetMarketCodes() ([]string, error) {
-	countries, countriesErr := l.GetCountries()
+	return l.GetMarketCodesWithContext(context.Background())
+}
+
+// GetMarketCodesWithContext is the same as GetMarketCodes, using the supplied context for the API call.
+func (l *Location) GetMarketCodesWithContext(ctx context.Context) ([]string, error) {
+	countries, countriesErr := l.GetCountriesWithContext(ctx)
 	var marketCodes []string
 
 	if countriesErr != nil {
@@ -177,7 +207,12 @@ func (l *Location) GetMarketCodes() ([]string, error) {
 }
 
 func (l *Location) IsValidMarketCode(marketCode string) bool {
-	marketCodes, _ := l.GetMarketCodes()
+	return l.IsValidMarketCodeWithContext(context.Background(), marketCode)
+}
+
+// IsValidMarketCodeWithContext is the same as IsValidMarketCode, using the supplied context for the API call.
+func (l *Location) IsValidMarketCodeWithContext(ctx context.Context, marketCode string) bool {
+	marketCodes, _ := l.GetMarketCodesWithContext(ctx)
 	found := false
 
 	for i := 0; i < len(marketCodes); i++ {
@@ -190,9 +225,15 @@ func (l *Location) IsValidMarketCode(marketCode string) bool {
 }
 
 func (l *Location) FilterLocationsByMarketCode(marketCode string, locations *[]types.Location) {
+	l.FilterLocationsByMarketCodeWithContext(context.Background(), marketCode, locations)
+}
+
+// FilterLocationsByMarketCodeWithContext is the same as FilterLocationsByMarketCode, using the supplied context for
+// the API call.
+func (l *Location) FilterLocationsByMarketCodeWithContext(ctx context.Context, marketCode string, locations *[]types.Location) {
 	existingLocations := *locations
 	*locations = nil
-	if l.IsValidMarketCode(marketCode) {
+	if l.IsValidMarketCodeWithContext(ctx, marketCode) {
 		for i := 0; i < len(existingLocations); i++ {
 			if existingLocations[i].Market == marketCode {
 				*locations = append(*locations, existingLocations[i])
@@ -213,8 +254,13 @@ func (l *Location) FilterLocationsByMcrAvailability(mcrAvailable bool, locations
 }
 
 func (l *Location) GetRandom(marketCode string) *types.Location {
-	testLocations, _ := l.GetAllLocations()
-	l.FilterLocationsByMarketCode(marketCode, &testLocations)
+	return l.GetRandomWithContext(context.Background(), marketCode)
+}
+
+// GetRandomWithContext is the same as GetRandom, using the supplied context for the API calls.
+func (l *Location) GetRandomWithContext(ctx context.Context, marketCode string) *types.Location {
+	testLocations, _ := l.GetAllLocationsWithContext(ctx)
+	l.FilterLocationsByMarketCodeWithContext(ctx, marketCode, &testLocations)
 	l.FilterLocationsByMcrAvailability(true, &testLocations)
 	testLocation := testLocations[shared.GenerateRandomNumber(0, len(testLocations)-1)]
 	return &testLocation
@@ -18,7 +18,6 @@
 package location
 
 import (
-	"errors"
 	"os"
 	"testing"
 
@@ -79,7 +78,7 @@ func TestBadID(t *testing.T) {
 
 	// Make sure that an id with no record returns an error as expected.
 	_, idErr := loc.GetLocationByID(-999999)
-	assert.Equal(mega_err.ERR_LOCATION_NOT_FOUND, idErr.Error())
+	assert.ErrorIs(idErr, mega_err.ErrLocationNotFound)
 }
 
 func TestBadName(t *testing.T) {
@@ -88,7 +87,7 @@ func TestBadName(t *testing.T) {
 
 	// Make sure that a name with no record returns an error as expected.
 	_, nameErr := loc.GetLocationByName("DefinitelyNotARealName")
-	assert.Equal(mega_err.ERR_LOCATION_NOT_FOUND, nameErr.Error())
+	assert.ErrorIs(nameErr, mega_err.ErrLocationNotFound)
 }
 
 func TestGetLocationByID(t *testing.T) {
@@ -133,7 +132,7 @@ func TestGetLocationByNameFuzzy(t *testing.T) {
 
 	failFuzzy, failFuzzyErr := loc.GetLocationByNameFuzzy("definitely not a location name at all")
 	assert.True(len(failFuzzy) == 0)
-	assert.Error(errors.New(mega_err.ERR_NO_MATCHING_LOCATIONS), failFuzzyErr)
+	assert.ErrorIs(failFuzzyErr, mega_err.ErrNoMatchingLocations)
 }
 
 // first one should always be Australia
@@ -17,17 +17,16 @@
 package mcr
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
-	"slices"
-	"time"
 
 	"github.com/megaport/megaportgo/config"
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/service/product"
-	"github.com/megaport/megaportgo/shared"
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 type MCR struct {
@@ -43,53 +42,92 @@ func New(cfg *config.Config) *MCR {
 	}
 }
 
-// BuyMCR purchases an MCR.
-func (m *MCR) BuyMCR(locationID int, name string, term int, portSpeed int, mcrASN int) (string, error) {
-	orderConfig := types.MCROrderConfig{}
+// BuyMCR purchases an MCR. See Buy to set the MCR's other order fields.
+func (m *MCR) BuyMCR(locationID int, name string, term int, portSpeed int, mcrASN int) (types.OrderResult, error) {
+	return m.BuyMCRWithContext(context.Background(), locationID, name, term, portSpeed, mcrASN)
+}
 
-	if term != 1 && term != 12 && term != 24 && term != 36 {
-		return "", errors.New(mega_err.ERR_TERM_NOT_VALID)
-	}
+// BuyMCRWithContext purchases an MCR, using the supplied context for the API call.
+func (m *MCR) BuyMCRWithContext(ctx context.Context, locationID int, name string, term int, portSpeed int, mcrASN int) (types.OrderResult, error) {
+	return m.BuyWithContext(ctx, mcrInput(locationID, name, term, portSpeed, mcrASN))
+}
 
-	if mcrASN != 0 {
-		orderConfig.ASN = mcrASN
-	}
+// Buy purchases the MCR described by input, after checking the input locally.
+func (m *MCR) Buy(input types.BuyMCRInput) (types.OrderResult, error) {
+	return m.BuyWithContext(context.Background(), input)
+}
 
-	if portSpeed != 1000 && portSpeed != 2500 && portSpeed != 5000 && portSpeed != 10000 {
-		return "", errors.New(mega_err.ERR_MCR_INVALID_PORT_SPEED)
+// BuyWithContext is the same as Buy, using the supplied context for the API call.
+func (m *MCR) BuyWithContext(ctx context.Context, input types.BuyMCRInput) (types.OrderResult, error) {
+	requestBody, err := buildMCROrder(input)
+	if err != nil {
+		return types.OrderResult{}, err
 	}
 
-	order := []types.MCROrder{
-		{
-			LocationID: locationID,
-			Name:       name,
-			Term:       term,
-			Type:       "MCR2",
-			PortSpeed:  portSpeed,
-			Config:     orderConfig,
-		},
-	}
+	return m.product.ExecuteOrderWithContext(ctx, &requestBody)
+}
+
+// ValidateMCROrder checks the order BuyMCR would place, without buying the MCR, and returns its price.
+func (m *MCR) ValidateMCROrder(locationID int, name string, term int, portSpeed int, mcrASN int) (types.OrderQuote, error) {
+	return m.ValidateMCROrderWithContext(context.Background(), locationID, name, term, portSpeed, mcrASN)
+}
+
+// ValidateMCROrderWithContext is the same as ValidateMCROrder, using the supplied context for the API call.
+func (m *MCR) ValidateMCROrderWithContext(ctx context.Context, locationID int, name string, term int, portSpeed int, mcrASN int) (types.OrderQuote, error) {
+	return m.ValidateOrderWithContext(ctx, mcrInput(locationID, name, term, portSpeed, mcrASN))
+}
 
-	requestBody, marshalErr := json.Marshal(order)
+// ValidateOrder checks the order Buy would place, without buying the MCR, and returns its price.
+func (m *MCR) ValidateOrder(input types.BuyMCRInput) (types.OrderQuote, error) {
+	return m.ValidateOrderWithContext(context.Background(), input)
+}
 
-	if marshalErr != nil {
-		return "", marshalErr
+// ValidateOrderWithContext is the same as ValidateOrder, using the supplied context for the API call.
+func (m *MCR) ValidateOrderWithContext(ctx context.Context, input types.BuyMCRInput) (types.OrderQuote, error) {
+	requestBody, err := buildMCROrder(input)
+	if err != nil {
+		return types.OrderQuote{}, err
 	}
 
-	body, resErr := m.product.ExecuteOrder(&requestBody)
+	return m.product.ValidateOrderWithContext(ctx, &requestBody)
+}
 
-	if resErr != nil {
-		return "", resErr
+// mcrInput returns the input for the positional arguments of BuyMCR.
+func mcrInput(locationID int, name string, term int, portSpeed int, mcrASN int) types.BuyMCRInput {
+	return types.BuyMCRInput{
+		LocationID: locationID,
+		Name:       name,
+		Term:       term,
+		PortSpeed:  portSpeed,
+		ASN:        mcrASN,
 	}
+}
 
-	orderInfo := types.MCROrderResponse{}
-	unmarshalErr := json.Unmarshal(*body, &orderInfo)
+// buildMCROrder returns the order for an MCR, as sent to the API by Buy and ValidateOrder.
+func buildMCROrder(input types.BuyMCRInput) ([]byte, error) {
+	order := types.MCROrder{
+		LocationID:            input.LocationID,
+		Name:                  input.Name,
+		Term:                  input.Term,
+		Type:                  "MCR2",
+		PortSpeed:             input.PortSpeed,
+		MarketplaceVisibility: input.MarketplaceVisibility,
+		CostCentre:            input.CostCentre,
+		PromoCode:             input.PromoCode,
+		ServiceLevelReference: input.ServiceLevelReference,
+		AttributeTags:         input.Tags,
+		Config: types.MCROrderConfig{
+			ASN:               input.ASN,
+			DiversityZone:     input.DiversityZone,
+			PrefixFilterLists: input.PrefixFilterLists,
+		},
+	}
 
-	if unmarshalErr != nil {
-		return "", unmarshalErr
+	if err := validation.ValidateMCROrder(order); err != nil {
+		return nil, err
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	return json.Marshal([]types.MCROrder{order})
 }
 
 // CreatePrefixFilterList creates a Prefix Filter List on an MCR.
@@ -98,17 +136,27 @@ func (m *MCR) CreatePrefixFilterList(id string, prefixFilterList types.MCRPrefix
 	return prefix, prefixErr
 }
 
+// CreatePrefixFilterListWithContext creates a Prefix Filter List on an MCR, using the supplied context for the API
+// call.
+func (m *MCR) CreatePrefixFilterListWithContext(ctx context.Context, id string, prefixFilterList types.MCRPrefixFilterList) (bool, error) {
+	return m.product.CreateMCRPrefixFilterListWithContext(ctx, id, prefixFilterList)
+}
+
 // GetMCRDetails get the details of an MCR.
 func (m *MCR) GetMCRDetails(id string) (types.MCR, error) {
-	url := "/v2/product/" + id
-	response, err := m.Config.MakeAPICall("GET", url, nil)
-	defer response.Body.Close()
+	return m.GetMCRDetailsWithContext(context.Background(), id)
+}
 
+// GetMCRDetailsWithContext get the details of an MCR, using the supplied context for the API call.
+func (m *MCR) GetMCRDetailsWithContext(ctx context.Context, id string) (types.MCR, error) {
+	url := "/v2/product/" + id
+	response, err := m.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
 	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
 
 	if isError {
 		return types.MCR{}, parsedError
 	}
+	defer response.Body.Close()
 
 	body, fileErr := io.ReadAll(response.Body)
 
@@ -131,33 +179,71 @@ func (m *MCR) ModifyMCR(mcrId string, name string, costCentre string, marketplac
 	return m.product.ModifyProduct(mcrId, types.PRODUCT_MCR, name, costCentre, marketplaceVisibility)
 }
 
+// ModifyMCRWithContext modifies an MCR, using the supplied context for the API call.
+func (m *MCR) ModifyMCRWithContext(ctx context.Context, mcrId string, name string, costCentre string, marketplaceVisibility bool) (bool, error) {
+	return m.product.ModifyProductWithContext(ctx, mcrId, types.PRODUCT_MCR, name, costCentre, marketplaceVisibility)
+}
+
 // ModifyMCR deletes an MCR.
 func (m *MCR) DeleteMCR(id string, deleteNow bool) (bool, error) {
 	return m.product.DeleteProduct(id, deleteNow)
 }
 
+// DeleteMCRWithContext deletes an MCR, using the supplied context for the API call.
+func (m *MCR) DeleteMCRWithContext(ctx context.Context, id string, deleteNow bool) (bool, error) {
+	return m.product.DeleteProductWithContext(ctx, id, deleteNow)
+}
+
 // ModifyMCR un-deletes an MCR.
 func (m *MCR) RestoreMCR(id string) (bool, error) {
 	return m.product.RestoreProduct(id)
 }
 
+// RestoreMCRWithContext un-deletes an MCR, using the supplied context for the API call.
+func (m *MCR) RestoreMCRWithContext(ctx context.Context, id string) (bool, error) {
+	return m.product.RestoreProductWithContext(ctx, id)
+}
+
+// LockMCR locks an MCR against changes. See Product.LockProduct for the errors returned.
+func (m *MCR) LockMCR(id string) (bool, error) {
+	return m.LockMCRWithContext(context.Background(), id)
+}
+
+// LockMCRWithContext is the same as LockMCR, using the supplied context for the API calls.
+func (m *MCR) LockMCRWithContext(ctx context.Context, id string) (bool, error) {
+	return m.product.LockProductWithContext(ctx, id)
+}
+
+// UnlockMCR unlocks a locked MCR. See Product.UnlockProduct for the errors returned.
+func (m *MCR) UnlockMCR(id string) (bool, error) {
+	return m.UnlockMCRWithContext(context.Background(), id)
+}
+
+// UnlockMCRWithContext is the same as UnlockMCR, using the supplied context for the API calls.
+func (m *MCR) UnlockMCRWithContext(ctx context.Context, id string) (bool, error) {
+	return m.product.UnlockProductWithContext(ctx, id)
+}
+
 // DebugWaitMCRLive should be used for testing only.
 func (m *MCR) WaitForMcrProvisioning(mcrId string) (bool, error) {
-	// Try for ~5mins.
-	for i := 0; i < 30; i++ {
-		details, err := m.GetMCRDetails(mcrId)
-		if err != nil {
-			return false, err
-		}
-
-		if slices.Contains(shared.SERVICE_STATE_READY, details.ProvisioningStatus) {
-			return true, nil
-		}
-
-		// Wrong status, wait a bit and try again.
-		m.Log.Debugf("MVE status is %q - waiting", details.ProvisioningStatus)
-		time.Sleep(10 * time.Second)
+	return m.WaitForMcrProvisioningWithContext(context.Background(), mcrId)
+}
+
+// WaitForMcrProvisioningWithContext is the same as WaitForMcrProvisioning, but stops waiting when the context is
+// cancelled.
+func (m *MCR) WaitForMcrProvisioningWithContext(ctx context.Context, mcrId string) (bool, error) {
+	return m.WaitForMcrProvisioningWithOptions(ctx, mcrId, product.WaitOptions{})
+}
+
+// WaitForMcrProvisioningWithOptions is the same as WaitForMcrProvisioningWithContext, polling according to opts instead
+// of for up to five minutes every ten seconds.
+func (m *MCR) WaitForMcrProvisioningWithOptions(ctx context.Context, mcrId string, opts product.WaitOptions) (bool, error) {
+	_, err := m.product.WaitForProduct(ctx, mcrId, product.ProvisioningReady, opts)
+	if errors.Is(err, mega_err.ErrWaitTimeoutExceed) {
+		return false, mega_err.ErrMCRProvisionTimeoutExceed
+	} else if err != nil {
+		return false, err
 	}
 
-	return false, errors.New(mega_err.ERR_MCR_PROVISION_TIMEOUT_EXCEED)
+	return true, nil
 }
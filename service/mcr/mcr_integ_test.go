@@ -87,7 +87,8 @@ func TestMCRLifecycle(t *testing.T) {
 	testLocation := location.GetRandom(TEST_MCR_TEST_LOCATION_MARKET)
 
 	logger.Debugf("Test location determined, Location: %s", testLocation.Name)
-	mcrId, portErr := mcr.BuyMCR(testLocation.ID, "Buy MCR", 1, 1000, 0)
+	mcrOrder, portErr := mcr.BuyMCR(testLocation.ID, "Buy MCR", 1, 1000, 0)
+	mcrId := mcrOrder.TechnicalServiceUID()
 
 	if !assert.NoError(portErr) && assert.False(shared.IsGuid(mcrId)) {
 		mcr.Config.PurchaseError(mcrId, portErr)
@@ -150,7 +151,8 @@ func TestMCRConnectionAdd(t *testing.T) {
 	logger.Infof("Test location determined, Location: %s", testLocation.Name)
 	logger.Debug("Buying MCR")
 
-	mcrId, mcrErr := mcr.BuyMCR(testLocation.ID, "MCR and AWS Interconnectivity", 1, 1000, 0)
+	mcrOrder, mcrErr := mcr.BuyMCR(testLocation.ID, "MCR and AWS Interconnectivity", 1, 1000, 0)
+	mcrId := mcrOrder.TechnicalServiceUID()
 
 	logger.Infof("MCR Purchased: %s", mcrId)
 
@@ -159,7 +161,7 @@ func TestMCRConnectionAdd(t *testing.T) {
 
 		logger.Info("Buying A")
 		logger.Info("Buying AWS VIF Connection (B End).")
-		vifOneId, vifOneErr := vxc.BuyAWSVXC(
+		vifOneOrder, vifOneErr := vxc.BuyAWSVXC(
 			mcrId,
 			"MCR and AWS Connection 1",
 			500,
@@ -179,8 +181,9 @@ func TestMCRConnectionAdd(t *testing.T) {
 				},
 			},
 		)
+		vifOneId := vifOneOrder.TechnicalServiceUID()
 
-		vifTwoId, vifTwoErr := vxc.BuyAWSVXC(
+		vifTwoOrder, vifTwoErr := vxc.BuyAWSVXC(
 			mcrId,
 			"MCR and AWS Connection 2",
 			500,
@@ -200,6 +203,7 @@ func TestMCRConnectionAdd(t *testing.T) {
 				},
 			},
 		)
+		vifTwoId := vifTwoOrder.TechnicalServiceUID()
 
 		logger.Infof("AWS VIF Connection 1: %s", vifOneId)
 		logger.Infof("AWS VIF Connection 2: %s", vifTwoId)
@@ -235,7 +239,7 @@ func TestPortSpeedValidation(t *testing.T) {
 
 	testLocation, _ := location.GetLocationByName("Global Switch Sydney")
 	_, buyErr := mcr.BuyMCR(testLocation.ID, "Test MCR", 1, 500, 0)
-	assert.EqualError(buyErr, mega_err.ERR_MCR_INVALID_PORT_SPEED)
+	assert.ErrorIs(buyErr, mega_err.ErrMCRInvalidPortSpeed)
 }
 
 func TestCreatePrefixFilterList(t *testing.T) {
@@ -247,7 +251,8 @@ func TestCreatePrefixFilterList(t *testing.T) {
 	testLocation := location.GetRandom(TEST_MCR_TEST_LOCATION_MARKET)
 
 	logger.Infof("Test location determined, Location: %s", testLocation.Name)
-	mcrId, portErr := mcr.BuyMCR(testLocation.ID, "Buy MCR", 1, 1000, 0)
+	mcrOrder, portErr := mcr.BuyMCR(testLocation.ID, "Buy MCR", 1, 1000, 0)
+	mcrId := mcrOrder.TechnicalServiceUID()
 
 	if !assert.NoError(portErr) && assert.False(shared.IsGuid(mcrId)) {
 		mcr.Config.PurchaseError(mcrId, portErr)
@@ -0,0 +1,109 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMCROrder(t *testing.T) {
+	prefixList := types.MCRPrefixFilterList{
+		Description:   "Internal",
+		AddressFamily: "IPv4",
+		Entries:       []types.MCRPrefixListEntry{{Action: "permit", Prefix: "10.0.0.0/8"}},
+	}
+
+	body, err := buildMCROrder(types.BuyMCRInput{
+		LocationID:        1,
+		Name:              "MCR",
+		Term:              12,
+		PortSpeed:         5000,
+		ASN:               65000,
+		DiversityZone:     "blue",
+		CostCentre:        "Networks",
+		PromoCode:         "PROMO",
+		Tags:              map[string]string{"environment": "production"},
+		PrefixFilterLists: []types.MCRPrefixFilterList{prefixList},
+	})
+	assert.NoError(t, err)
+
+	var orders []types.MCROrder
+	assert.NoError(t, json.Unmarshal(body, &orders))
+	assert.Len(t, orders, 1)
+
+	order := orders[0]
+	assert.Equal(t, "MCR2", order.Type)
+	assert.Equal(t, 65000, order.Config.ASN)
+	assert.Equal(t, "blue", order.Config.DiversityZone)
+	assert.Equal(t, []types.MCRPrefixFilterList{prefixList}, order.Config.PrefixFilterLists)
+	assert.Equal(t, "Networks", order.CostCentre)
+	assert.Equal(t, "PROMO", order.PromoCode)
+	assert.Equal(t, types.Tags{"environment": "production"}, order.AttributeTags)
+}
+
+// The positional BuyMCR sends the same order as before Buy was added.
+func TestBuildMCROrderPositional(t *testing.T) {
+	body, err := buildMCROrder(mcrInput(1, "MCR", 12, 1000, 65000))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"locationId":1,"productName":"MCR","term":12,"productType":"MCR2","portSpeed":1000,
+		"config":{"mcrAsn":65000}}]`, string(body))
+
+	body, err = buildMCROrder(mcrInput(1, "MCR", 12, 1000, 0))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"locationId":1,"productName":"MCR","term":12,"productType":"MCR2","portSpeed":1000,
+		"config":{}}]`, string(body))
+
+	hidden := false
+	input := mcrInput(1, "MCR", 12, 1000, 0)
+	input.MarketplaceVisibility = &hidden
+	body, err = buildMCROrder(input)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"marketplaceVisibility":false`)
+}
+
+func TestBuildMCROrderInvalid(t *testing.T) {
+	valid := mcrInput(1, "MCR", 1, 1000, 0)
+
+	_, err := buildMCROrder(valid)
+	assert.NoError(t, err)
+
+	input := valid
+	input.Term = 48
+	_, err = buildMCROrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+
+	input = valid
+	input.PortSpeed = 100
+	_, err = buildMCROrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrMCRInvalidPortSpeed)
+
+	input = valid
+	input.LocationID = 0
+	_, err = buildMCROrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+
+	input = valid
+	input.PrefixFilterLists = []types.MCRPrefixFilterList{{Description: "List", AddressFamily: "IPX"}}
+	_, err = buildMCROrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+}
@@ -17,18 +17,17 @@
 package mve
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
-	"slices"
 	"strings"
-	"time"
 
 	"github.com/megaport/megaportgo/config"
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/service/product"
-	"github.com/megaport/megaportgo/shared"
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 // MVE provides an interface for configuring and ordering MVE instances.
@@ -42,50 +41,109 @@ func New(cfg *config.Config) *MVE {
 	return &MVE{cfg, product.New(cfg)}
 }
 
-// BuyMVE purchases an MVE.
-func (m *MVE) BuyMVE(locationID int, name string, term int, config map[string]interface{}, vnics []*types.MVENetworkInterface) (string, error) {
-	// Create a default vNIC if none specified.
-	if len(vnics) == 0 {
-		vnics = []*types.MVENetworkInterface{{Description: "Data Plane"}}
+// BuyMVE purchases an MVE. See Buy to set the MVE's other order fields.
+func (m *MVE) BuyMVE(locationID int, name string, term int, config map[string]interface{}, vnics []*types.MVENetworkInterface) (types.OrderResult, error) {
+	return m.BuyMVEWithContext(context.Background(), locationID, name, term, config, vnics)
+}
+
+// BuyMVEWithContext purchases an MVE, using the supplied context for the API call.
+func (m *MVE) BuyMVEWithContext(ctx context.Context, locationID int, name string, term int, config map[string]interface{}, vnics []*types.MVENetworkInterface) (types.OrderResult, error) {
+	return m.BuyWithContext(ctx, mveInput(locationID, name, term, config, vnics))
+}
+
+// Buy purchases the MVE described by input, after checking the input locally.
+func (m *MVE) Buy(input types.BuyMVEInput) (types.OrderResult, error) {
+	return m.BuyWithContext(context.Background(), input)
+}
+
+// BuyWithContext is the same as Buy, using the supplied context for the API call.
+func (m *MVE) BuyWithContext(ctx context.Context, input types.BuyMVEInput) (types.OrderResult, error) {
+	requestBody, err := buildMVEOrder(input)
+	if err != nil {
+		return types.OrderResult{}, err
 	}
 
-	if term != 1 && term != 12 && term != 24 && term != 36 {
-		return "", errors.New(mega_err.ERR_TERM_NOT_VALID)
+	return m.product.ExecuteOrderWithContext(ctx, &requestBody)
+}
+
+// ValidateMVEOrder checks the order BuyMVE would place, without buying the MVE, and returns its price.
+func (m *MVE) ValidateMVEOrder(locationID int, name string, term int, config map[string]interface{}, vnics []*types.MVENetworkInterface) (types.OrderQuote, error) {
+	return m.ValidateMVEOrderWithContext(context.Background(), locationID, name, term, config, vnics)
+}
+
+// ValidateMVEOrderWithContext is the same as ValidateMVEOrder, using the supplied context for the API call.
+func (m *MVE) ValidateMVEOrderWithContext(ctx context.Context, locationID int, name string, term int, config map[string]interface{}, vnics []*types.MVENetworkInterface) (types.OrderQuote, error) {
+	return m.ValidateOrderWithContext(ctx, mveInput(locationID, name, term, config, vnics))
+}
+
+// ValidateOrder checks the order Buy would place, without buying the MVE, and returns its price.
+func (m *MVE) ValidateOrder(input types.BuyMVEInput) (types.OrderQuote, error) {
+	return m.ValidateOrderWithContext(context.Background(), input)
+}
+
+// ValidateOrderWithContext is the same as ValidateOrder, using the supplied context for the API call.
+func (m *MVE) ValidateOrderWithContext(ctx context.Context, input types.BuyMVEInput) (types.OrderQuote, error) {
+	requestBody, err := buildMVEOrder(input)
+	if err != nil {
+		return types.OrderQuote{}, err
 	}
 
-	order := []*types.MVEOrderConfig{{
+	return m.product.ValidateOrderWithContext(ctx, &requestBody)
+}
+
+// mveInput returns the input for the positional arguments of BuyMVE.
+func mveInput(locationID int, name string, term int, config map[string]interface{}, vnics []*types.MVENetworkInterface) types.BuyMVEInput {
+	return types.BuyMVEInput{
 		LocationID:        locationID,
 		Name:              name,
 		Term:              term,
-		ProductType:       strings.ToUpper(types.PRODUCT_MVE),
-		NetworkInterfaces: vnics,
 		VendorConfig:      config,
-	}}
+		NetworkInterfaces: vnics,
+	}
+}
 
-	requestBody, err := json.Marshal(order)
-	if err != nil {
-		return "", err
+// buildMVEOrder returns the order for an MVE, as sent to the API by Buy and ValidateOrder.
+func buildMVEOrder(input types.BuyMVEInput) ([]byte, error) {
+	// Create a default vNIC if none specified.
+	vnics := input.NetworkInterfaces
+	if len(vnics) == 0 {
+		vnics = []*types.MVENetworkInterface{{Description: "Data Plane"}}
 	}
 
-	body, err := m.product.ExecuteOrder(&requestBody)
-	if err != nil {
-		return "", err
+	order := types.MVEOrderConfig{
+		LocationID:            input.LocationID,
+		Name:                  input.Name,
+		Term:                  input.Term,
+		ProductType:           strings.ToUpper(types.PRODUCT_MVE),
+		NetworkInterfaces:     vnics,
+		VendorConfig:          input.VendorConfig,
+		DiversityZone:         input.DiversityZone,
+		CostCentre:            input.CostCentre,
+		PromoCode:             input.PromoCode,
+		ServiceLevelReference: input.ServiceLevelReference,
+		AttributeTags:         input.Tags,
 	}
 
-	orderInfo := types.MVEOrderResponse{}
-	if err := json.Unmarshal(*body, &orderInfo); err != nil {
-		return "", err
+	if err := validation.ValidateMVEOrder(order); err != nil {
+		return nil, err
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	return json.Marshal([]types.MVEOrderConfig{order})
 }
 
 // GetMVEDetails returns the details of a configured MVE.
 func (m *MVE) GetMVEDetails(uid string) (*types.MVE, error) {
+	return m.GetMVEDetailsWithContext(context.Background(), uid)
+}
+
+// GetMVEDetailsWithContext returns the details of a configured MVE, using the supplied context for the API call.
+func (m *MVE) GetMVEDetailsWithContext(ctx context.Context, uid string) (*types.MVE, error) {
 	url := "/v2/product/" + uid
-	res, err := m.Config.MakeAPICall("GET", url, nil)
-	if err != nil {
-		return nil, err
+	res, err := m.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
+	isError, parsedError := m.Config.IsErrorResponse(res, &err, 200)
+
+	if isError {
+		return nil, parsedError
 	}
 	defer res.Body.Close()
 
@@ -106,26 +164,59 @@ func (m *MVE) ModifyMVE(uid string, name string) (bool, error) {
 	return m.product.ModifyProduct(uid, types.PRODUCT_MVE, name, "", false)
 }
 
+// ModifyMVEWithContext is the same as ModifyMVE, using the supplied context for the API call.
+func (m *MVE) ModifyMVEWithContext(ctx context.Context, uid string, name string) (bool, error) {
+	return m.product.ModifyProductWithContext(ctx, uid, types.PRODUCT_MVE, name, "", false)
+}
+
 func (m *MVE) DeleteMVE(uid string) (bool, error) {
 	return m.product.DeleteProduct(uid, true)
 }
 
+// DeleteMVEWithContext is the same as DeleteMVE, using the supplied context for the API call.
+func (m *MVE) DeleteMVEWithContext(ctx context.Context, uid string) (bool, error) {
+	return m.product.DeleteProductWithContext(ctx, uid, true)
+}
+
+// LockMVE locks an MVE against changes. See Product.LockProduct for the errors returned.
+func (m *MVE) LockMVE(id string) (bool, error) {
+	return m.LockMVEWithContext(context.Background(), id)
+}
+
+// LockMVEWithContext is the same as LockMVE, using the supplied context for the API calls.
+func (m *MVE) LockMVEWithContext(ctx context.Context, id string) (bool, error) {
+	return m.product.LockProductWithContext(ctx, id)
+}
+
+// UnlockMVE unlocks a locked MVE. See Product.UnlockProduct for the errors returned.
+func (m *MVE) UnlockMVE(id string) (bool, error) {
+	return m.UnlockMVEWithContext(context.Background(), id)
+}
+
+// UnlockMVEWithContext is the same as UnlockMVE, using the supplied context for the API calls.
+func (m *MVE) UnlockMVEWithContext(ctx context.Context, id string) (bool, error) {
+	return m.product.UnlockProductWithContext(ctx, id)
+}
+
 func (m *MVE) WaitForMVEProvisioning(uid string) (bool, error) {
-	// Try for ~5mins.
-	for i := 0; i < 30; i++ {
-		details, err := m.GetMVEDetails(uid)
-		if err != nil {
-			return false, err
-		}
-
-		if slices.Contains(shared.SERVICE_STATE_READY, details.ProvisioningStatus) {
-			return true, nil
-		}
-
-		// Wrong status, wait a bit and try again.
-		m.Log.Debugf("MVE status is %q - waiting", details.ProvisioningStatus)
-		time.Sleep(10 * time.Second)
+	return m.WaitForMVEProvisioningWithContext(context.Background(), uid)
+}
+
+// WaitForMVEProvisioningWithContext is the same as WaitForMVEProvisioning, but stops waiting when the context is
+// cancelled.
+func (m *MVE) WaitForMVEProvisioningWithContext(ctx context.Context, uid string) (bool, error) {
+	return m.WaitForMVEProvisioningWithOptions(ctx, uid, product.WaitOptions{})
+}
+
+// WaitForMVEProvisioningWithOptions is the same as WaitForMVEProvisioningWithContext, polling according to opts instead
+// of for up to five minutes every ten seconds.
+func (m *MVE) WaitForMVEProvisioningWithOptions(ctx context.Context, uid string, opts product.WaitOptions) (bool, error) {
+	_, err := m.product.WaitForProduct(ctx, uid, product.ProvisioningReady, opts)
+	if errors.Is(err, mega_err.ErrWaitTimeoutExceed) {
+		return false, mega_err.ErrMVEProvisionTimeoutExceed
+	} else if err != nil {
+		return false, err
 	}
 
-	return false, errors.New(mega_err.ERR_MVE_PROVISION_TIMEOUT_EXCEED)
+	return true, nil
 }
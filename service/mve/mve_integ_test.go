@@ -97,7 +97,8 @@ func TestC8KVAutoLifecycle(t *testing.T) {
 		"sshPublicKey": readSSHPubKey(),
 	}
 
-	mveUid, err := mve.BuyMVE(testLocation.ID, "MVE Test", 12, mveConfig, nil)
+	mveOrder, err := mve.BuyMVE(testLocation.ID, "MVE Test", 12, mveConfig, nil)
+	mveUid := mveOrder.TechnicalServiceUID()
 
 	if !assert.NoError(err) && assert.False(shared.IsGuid(mveUid)) {
 		mve.Config.PurchaseError(mveUid, err)
@@ -0,0 +1,92 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMVEOrder(t *testing.T) {
+	body, err := buildMVEOrder(types.BuyMVEInput{
+		LocationID:    1,
+		Name:          "MVE",
+		Term:          12,
+		VendorConfig:  map[string]interface{}{"vendor": "cisco", "productSize": "SMALL"},
+		DiversityZone: "red",
+		CostCentre:    "Networks",
+		Tags:          map[string]string{"owner": "networks"},
+	})
+	assert.NoError(t, err)
+
+	var orders []types.MVEOrderConfig
+	assert.NoError(t, json.Unmarshal(body, &orders))
+	assert.Len(t, orders, 1)
+
+	order := orders[0]
+	assert.Equal(t, "MVE", order.ProductType)
+	assert.Equal(t, []*types.MVENetworkInterface{{Description: "Data Plane"}}, order.NetworkInterfaces)
+	assert.Equal(t, "red", order.DiversityZone)
+	assert.Equal(t, "Networks", order.CostCentre)
+	assert.Equal(t, types.Tags{"owner": "networks"}, order.AttributeTags)
+}
+
+func TestBuildMVEOrderInvalid(t *testing.T) {
+	valid := mveInput(1, "MVE", 1, map[string]interface{}{"vendor": "cisco"}, nil)
+
+	_, err := buildMVEOrder(valid)
+	assert.NoError(t, err)
+
+	input := valid
+	input.Term = 2
+	_, err = buildMVEOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+
+	input = valid
+	input.VendorConfig = nil
+	_, err = buildMVEOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+
+	input = valid
+	input.NetworkInterfaces = []*types.MVENetworkInterface{nil}
+	_, err = buildMVEOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+}
+
+func TestGetMVEDetailsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Could not find a service with UID missing","terms":"","data":""}`))
+	}))
+	defer server.Close()
+
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+	mveService := New(&config.Config{Log: logger, Endpoint: server.URL, Client: server.Client()})
+
+	details, err := mveService.GetMVEDetails("missing")
+	assert.True(t, mega_err.IsNotFound(err))
+	assert.Nil(t, details)
+}
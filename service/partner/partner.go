@@ -16,8 +16,8 @@
 package partner
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"io"
 
 	"github.com/lithammer/fuzzysearch/fuzzy"
@@ -38,9 +38,15 @@ func New(cfg *config.Config) *Partner {
 
 // GetAllPartnerMegaports gets a list of all partner megaports in the Megaport Marketplace.
 func (p *Partner) GetAllPartnerMegaports() ([]types.PartnerMegaport, error) {
+	return p.GetAllPartnerMegaportsWithContext(context.Background())
+}
+
+// GetAllPartnerMegaportsWithContext gets a list of all partner megaports in the Megaport Marketplace, using the
+// supplied context for the API call.
+func (p *Partner) GetAllPartnerMegaportsWithContext(ctx context.Context) ([]types.PartnerMegaport, error) {
 	partnerMegaportUrl := "/v2/dropdowns/partner/megaports"
 
-	response, resErr := p.Config.MakeAPICall("GET", partnerMegaportUrl, nil)
+	response, resErr := p.Config.MakeAPICallWithContext(ctx, "GET", partnerMegaportUrl, nil)
 	isResErr, parsedResErr := p.Config.IsErrorResponse(response, &resErr, 200)
 
 	if isResErr {
@@ -93,7 +99,7 @@ func (p *Partner) FilterPartnerMegaportByProductName(partnerMegaports *[]types.P
 	*partnerMegaports = filteredMegaports
 
 	if len(*partnerMegaports) == 0 {
-		return errors.New(mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+		return mega_err.ErrPartnerPortNoResults
 	} else {
 		return nil
 	}
@@ -128,7 +134,7 @@ func (p *Partner) FilterPartnerMegaportByConnectType(partnerMegaports *[]types.P
 	*partnerMegaports = filteredMegaports
 
 	if len(*partnerMegaports) == 0 {
-		return errors.New(mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+		return mega_err.ErrPartnerPortNoResults
 	} else {
 		return nil
 	}
@@ -163,7 +169,7 @@ func (p *Partner) FilterPartnerMegaportByCompanyName(partnerMegaports *[]types.P
 	*partnerMegaports = filteredMegaports
 
 	if len(*partnerMegaports) == 0 {
-		return errors.New(mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+		return mega_err.ErrPartnerPortNoResults
 	} else {
 		return nil
 	}
@@ -186,7 +192,7 @@ func (p *Partner) FilterPartnerMegaportByLocationId(partnerMegaports *[]types.Pa
 	*partnerMegaports = filteredMegaports
 
 	if len(*partnerMegaports) == 0 {
-		return errors.New(mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+		return mega_err.ErrPartnerPortNoResults
 	} else {
 		return nil
 	}
@@ -217,7 +223,7 @@ func (p *Partner) FilterPartnerMegaportByDiversityZone(partnerMegaports *[]types
 	*partnerMegaports = filteredMegaports
 
 	if len(*partnerMegaports) == 0 {
-		return errors.New(mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+		return mega_err.ErrPartnerPortNoResults
 	} else {
 		return nil
 	}
@@ -17,17 +17,17 @@
 package port
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
-	"slices"
 	"time"
 
 	"github.com/megaport/megaportgo/config"
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/service/product"
-	"github.com/megaport/megaportgo/shared"
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 const MODIFY_NAME string = "NAME"
@@ -46,82 +46,142 @@ func New(cfg *config.Config) *Port {
 	}
 }
 
-// BuyPort orders a Port.
-func (p *Port) BuyPort(name string, term int, portSpeed int, locationId int, market string, isLAG bool, lagCount int, isPrivate bool) (string, error) {
-	var buyOrder []types.PortOrder
+// BuyPort orders a Port. See Buy to set the Port's other order fields.
+func (p *Port) BuyPort(name string, term int, portSpeed int, locationId int, market string, isLAG bool, lagCount int, isPrivate bool) (types.OrderResult, error) {
+	return p.BuyPortWithContext(context.Background(), name, term, portSpeed, locationId, market, isLAG, lagCount, isPrivate)
+}
+
+// BuyPortWithContext orders a Port, using the supplied context for the API call.
+func (p *Port) BuyPortWithContext(ctx context.Context, name string, term int, portSpeed int, locationId int, market string, isLAG bool, lagCount int, isPrivate bool) (types.OrderResult, error) {
+	return p.BuyWithContext(ctx, portInput(name, term, portSpeed, locationId, market, isLAG, lagCount, isPrivate))
+}
+
+// Buy orders the Port described by input, after checking the input locally.
+func (p *Port) Buy(input types.BuyPortInput) (types.OrderResult, error) {
+	return p.BuyWithContext(context.Background(), input)
+}
 
-	if term != 1 && term != 12 && term != 24 && term != 36 {
-		return "", errors.New(mega_err.ERR_TERM_NOT_VALID)
+// BuyWithContext is the same as Buy, using the supplied context for the API call.
+func (p *Port) BuyWithContext(ctx context.Context, input types.BuyPortInput) (types.OrderResult, error) {
+	requestBody, err := buildPortOrder(input)
+	if err != nil {
+		return types.OrderResult{}, err
 	}
 
-	if isLAG {
-		buyOrder = []types.PortOrder{
-			{
-				Name:                  name,
-				Term:                  term,
-				ProductType:           "MEGAPORT",
-				PortSpeed:             portSpeed,
-				LocationID:            locationId,
-				CreateDate:            shared.GetCurrentTimestamp(),
-				Virtual:               false,
-				Market:                market,
-				LagPortCount:          lagCount,
-				MarketplaceVisibility: !isPrivate,
-			},
-		}
-	} else {
-		buyOrder = []types.PortOrder{
-			{
-				Name:                  name,
-				Term:                  term,
-				ProductType:           "MEGAPORT",
-				PortSpeed:             portSpeed,
-				LocationID:            locationId,
-				CreateDate:            shared.GetCurrentTimestamp(),
-				Virtual:               false,
-				Market:                market,
-				MarketplaceVisibility: !isPrivate,
-			},
-		}
+	return p.product.ExecuteOrderWithContext(ctx, &requestBody)
+}
+
+// ValidatePortOrder checks the order BuyPort would place, without buying the port, and returns its price.
+func (p *Port) ValidatePortOrder(name string, term int, portSpeed int, locationId int, market string, isLAG bool, lagCount int, isPrivate bool) (types.OrderQuote, error) {
+	return p.ValidatePortOrderWithContext(context.Background(), name, term, portSpeed, locationId, market, isLAG, lagCount, isPrivate)
+}
+
+// ValidatePortOrderWithContext is the same as ValidatePortOrder, using the supplied context for the API call.
+func (p *Port) ValidatePortOrderWithContext(ctx context.Context, name string, term int, portSpeed int, locationId int, market string, isLAG bool, lagCount int, isPrivate bool) (types.OrderQuote, error) {
+	return p.ValidateOrderWithContext(ctx, portInput(name, term, portSpeed, locationId, market, isLAG, lagCount, isPrivate))
+}
+
+// ValidateOrder checks the order Buy would place, without buying the port, and returns its price.
+func (p *Port) ValidateOrder(input types.BuyPortInput) (types.OrderQuote, error) {
+	return p.ValidateOrderWithContext(context.Background(), input)
+}
+
+// ValidateOrderWithContext is the same as ValidateOrder, using the supplied context for the API call.
+func (p *Port) ValidateOrderWithContext(ctx context.Context, input types.BuyPortInput) (types.OrderQuote, error) {
+	requestBody, err := buildPortOrder(input)
+	if err != nil {
+		return types.OrderQuote{}, err
+	}
+
+	return p.product.ValidateOrderWithContext(ctx, &requestBody)
+}
+
+// portInput returns the input for the positional arguments of BuyPort.
+func portInput(name string, term int, portSpeed int, locationId int, market string, isLAG bool, lagCount int, isPrivate bool) types.BuyPortInput {
+	return types.BuyPortInput{
+		Name:                  name,
+		Term:                  term,
+		PortSpeed:             portSpeed,
+		LocationID:            locationId,
+		Market:                market,
+		IsLAG:                 isLAG,
+		LagCount:              lagCount,
+		MarketplaceVisibility: !isPrivate,
 	}
+}
 
-	requestBody, _ := json.Marshal(buyOrder)
-	responseBody, responseErr := p.product.ExecuteOrder(&requestBody)
+// buildPortOrder returns the order for a port, as sent to the API by Buy and ValidateOrder.
+func buildPortOrder(input types.BuyPortInput) ([]byte, error) {
+	order := types.PortOrder{
+		Name:                  input.Name,
+		Term:                  input.Term,
+		ProductType:           "MEGAPORT",
+		PortSpeed:             input.PortSpeed,
+		LocationID:            input.LocationID,
+		CreateDate:            types.NewTimestamp(time.Now()),
+		Virtual:               false,
+		Market:                input.Market,
+		MarketplaceVisibility: input.MarketplaceVisibility,
+		DiversityZone:         input.DiversityZone,
+		CostCentre:            input.CostCentre,
+		PromoCode:             input.PromoCode,
+		ServiceLevelReference: input.ServiceLevelReference,
+		AttributeTags:         input.Tags,
+	}
 
-	if responseErr != nil {
-		return "", responseErr
+	if input.IsLAG {
+		order.LagPortCount = input.LagCount
 	}
 
-	orderInfo := types.PortOrderResponse{}
-	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
+	var errs validation.Errors
+	errs.Add("", validation.ValidatePortOrder(order))
 
-	if unmarshalErr != nil {
-		return "", unmarshalErr
+	// A LAG count of 0 is valid in the order, where it means a single port, but not for a LAG.
+	if input.IsLAG && input.LagCount == 0 {
+		errs.Add("lagPortCount", errors.New("invalid LAG count 0, a LAG has between 1 and 8 ports"))
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	return json.Marshal([]types.PortOrder{order})
 }
 
 // BuyPort orders a single Port. Same as BuyPort, with isLag set to false.
-func (p *Port) BuySinglePort(name string, term int, portSpeed int, locationId int, market string, isPrivate bool) (string, error) {
+func (p *Port) BuySinglePort(name string, term int, portSpeed int, locationId int, market string, isPrivate bool) (types.OrderResult, error) {
 	return p.BuyPort(name, term, portSpeed, locationId, market, false, 0, isPrivate)
 }
 
+// BuySinglePortWithContext orders a single Port. Same as BuyPortWithContext, with isLag set to false.
+func (p *Port) BuySinglePortWithContext(ctx context.Context, name string, term int, portSpeed int, locationId int, market string, isPrivate bool) (types.OrderResult, error) {
+	return p.BuyPortWithContext(ctx, name, term, portSpeed, locationId, market, false, 0, isPrivate)
+}
+
 // BuyPort orders a LAG Port. Same as BuyPort, with isLag set to true.
-func (p *Port) BuyLAGPort(name string, term int, portSpeed int, locationId int, market string, lagCount int, isPrivate bool) (string, error) {
+func (p *Port) BuyLAGPort(name string, term int, portSpeed int, locationId int, market string, lagCount int, isPrivate bool) (types.OrderResult, error) {
 	return p.BuyPort(name, term, portSpeed, locationId, market, true, lagCount, isPrivate)
 }
 
+// BuyLAGPortWithContext orders a LAG Port. Same as BuyPortWithContext, with isLag set to true.
+func (p *Port) BuyLAGPortWithContext(ctx context.Context, name string, term int, portSpeed int, locationId int, market string, lagCount int, isPrivate bool) (types.OrderResult, error) {
+	return p.BuyPortWithContext(ctx, name, term, portSpeed, locationId, market, true, lagCount, isPrivate)
+}
+
 func (p *Port) GetPortDetails(id string) (types.Port, error) {
-	url := "/v2/product/" + id
-	response, err := p.Config.MakeAPICall("GET", url, nil)
-	defer response.Body.Close()
+	return p.GetPortDetailsWithContext(context.Background(), id)
+}
 
+// GetPortDetailsWithContext is the same as GetPortDetails, using the supplied context for the API call.
+func (p *Port) GetPortDetailsWithContext(ctx context.Context, id string) (types.Port, error) {
+	url := "/v2/product/" + id
+	response, err := p.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
 	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
 
 	if isError {
 		return types.Port{}, parsedError
 	}
+	defer response.Body.Close()
 
 	body, fileErr := io.ReadAll(response.Body)
 
@@ -139,59 +199,31 @@ func (p *Port) GetPortDetails(id string) (types.Port, error) {
 	return portDetails.Data, nil
 }
 
+// Deprecated: GetPorts now decodes products with types.ProductListResponse.
 type ParsedProductsResponse struct {
 	Message string        `json:"message"`
 	Terms   string        `json:"terms"`
 	Data    []interface{} `json:"data"`
 }
 
+// GetPorts returns every Port in the account.
 func (p *Port) GetPorts() ([]types.Port, error) {
-	url := "/v2/products"
-	response, err := p.Config.MakeAPICall("GET", url, nil)
-	defer response.Body.Close()
-
-	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
-
-	if isError {
-		return []types.Port{}, parsedError
-	}
-
-	body, fileErr := io.ReadAll(response.Body)
-
-	if fileErr != nil {
-		return []types.Port{}, fileErr
-	}
-
-	parsed := ParsedProductsResponse{}
-
-	unmarshalErr := json.Unmarshal(body, &parsed)
+	return p.GetPortsWithContext(context.Background())
+}
 
-	if unmarshalErr != nil {
-		return []types.Port{}, unmarshalErr
+// GetPortsWithContext is the same as GetPorts, using the supplied context for the API call.
+func (p *Port) GetPortsWithContext(ctx context.Context) ([]types.Port, error) {
+	products, err := p.product.ListProductsWithContext(ctx)
+	if err != nil {
+		return []types.Port{}, err
 	}
 
 	var ports []types.Port
 
-	for _, unmarshaledData := range parsed.Data {
-		// The products query response will likely contain non-port objects.  As a result
-		// we need to initially Unmarshal as ParsedProductsResponse so that we may iterate
-		// over the entries in Data then re-Marshal those entries so that we may Unmarshal
-		// them as Port (and `continue` where that doesn't work).  We could write a custom
-		// deserializer to avoid this but that is a lot of work for a performance
-		// optimization which is likely irrelevant in practice.
-		// Unfortunately I know of no better (maintainable) method of making this work.
-		remarshaled, err := json.Marshal(unmarshaledData)
-		if err != nil {
-			p.Log.Debugln("Could not remarshal %v as port.", err.Error())
-			continue
+	for _, product := range products {
+		if port, ok := product.(*types.Port); ok {
+			ports = append(ports, *port)
 		}
-		port := types.Port{}
-		unmarshalErr = json.Unmarshal(remarshaled, &port)
-		if unmarshalErr != nil {
-			p.Log.Debugln("Could not unmarshal %v as port.", unmarshalErr.Error())
-			continue
-		}
-		ports = append(ports, port)
 	}
 
 	return ports, nil
@@ -201,49 +233,78 @@ func (p *Port) ModifyPort(portId string, name string, costCentre string, marketp
 	return p.product.ModifyProduct(portId, types.PRODUCT_MEGAPORT, name, costCentre, marketplaceVisibility)
 }
 
+// ModifyPortWithContext is the same as ModifyPort, using the supplied context for the API call.
+func (p *Port) ModifyPortWithContext(ctx context.Context, portId string, name string, costCentre string, marketplaceVisibility bool) (bool, error) {
+	return p.product.ModifyProductWithContext(ctx, portId, types.PRODUCT_MEGAPORT, name, costCentre, marketplaceVisibility)
+}
+
 func (p *Port) DeletePort(id string, deleteNow bool) (bool, error) {
 	return p.product.DeleteProduct(id, deleteNow)
 }
 
+// DeletePortWithContext is the same as DeletePort, using the supplied context for the API call.
+func (p *Port) DeletePortWithContext(ctx context.Context, id string, deleteNow bool) (bool, error) {
+	return p.product.DeleteProductWithContext(ctx, id, deleteNow)
+}
+
 func (p *Port) RestorePort(id string) (bool, error) {
 	return p.product.RestoreProduct(id)
 }
 
-// TODO: Tests for locking.
+// RestorePortWithContext is the same as RestorePort, using the supplied context for the API call.
+func (p *Port) RestorePortWithContext(ctx context.Context, id string) (bool, error) {
+	return p.product.RestoreProductWithContext(ctx, id)
+}
+
+// LockPort locks a Port against changes. It returns mega_err.ErrPortAlreadyLocked if the Port is already locked. See
+// Product.LockProduct for the other errors returned.
 func (p *Port) LockPort(id string) (bool, error) {
-	portInfo, _ := p.GetPortDetails(id)
-	if !portInfo.Locked {
-		return p.product.ManageProductLock(id, true)
-	} else {
-		return true, errors.New(mega_err.ERR_PORT_ALREADY_LOCKED)
+	return p.LockPortWithContext(context.Background(), id)
+}
+
+// LockPortWithContext is the same as LockPort, using the supplied context for the API calls.
+func (p *Port) LockPortWithContext(ctx context.Context, id string) (bool, error) {
+	locked, err := p.product.LockProductWithContext(ctx, id)
+	if errors.Is(err, mega_err.ErrProductAlreadyLocked) {
+		return true, mega_err.ErrPortAlreadyLocked
 	}
+	return locked, err
 }
 
+// UnlockPort unlocks a locked Port. It returns mega_err.ErrPortNotLocked if the Port is not locked. See
+// Product.UnlockProduct for the other errors returned.
 func (p *Port) UnlockPort(id string) (bool, error) {
-	portInfo, _ := p.GetPortDetails(id)
-	if portInfo.Locked {
-		return p.product.ManageProductLock(id, false)
-	} else {
-		return true, errors.New(mega_err.ERR_PORT_NOT_LOCKED)
+	return p.UnlockPortWithContext(context.Background(), id)
+}
+
+// UnlockPortWithContext is the same as UnlockPort, using the supplied context for the API calls.
+func (p *Port) UnlockPortWithContext(ctx context.Context, id string) (bool, error) {
+	unlocked, err := p.product.UnlockProductWithContext(ctx, id)
+	if errors.Is(err, mega_err.ErrProductNotLocked) {
+		return true, mega_err.ErrPortNotLocked
 	}
+	return unlocked, err
 }
 
 func (p *Port) WaitForPortProvisioning(portId string) (bool, error) {
-	// Try for ~5mins.
-	for i := 0; i < 30; i++ {
-		details, err := p.GetPortDetails(portId)
-		if err != nil {
-			return false, err
-		}
+	return p.WaitForPortProvisioningWithContext(context.Background(), portId)
+}
 
-		if slices.Contains(shared.SERVICE_STATE_READY, details.ProvisioningStatus) {
-			return true, nil
-		}
+// WaitForPortProvisioningWithContext is the same as WaitForPortProvisioning, but stops waiting when the context is
+// cancelled.
+func (p *Port) WaitForPortProvisioningWithContext(ctx context.Context, portId string) (bool, error) {
+	return p.WaitForPortProvisioningWithOptions(ctx, portId, product.WaitOptions{})
+}
 
-		// Wrong status, wait a bit and try again.
-		p.Log.Debugf("Port status is currently %q - waiting", details.ProvisioningStatus)
-		time.Sleep(10 * time.Second)
+// WaitForPortProvisioningWithOptions is the same as WaitForPortProvisioningWithContext, polling according to opts
+// instead of for up to five minutes every ten seconds.
+func (p *Port) WaitForPortProvisioningWithOptions(ctx context.Context, portId string, opts product.WaitOptions) (bool, error) {
+	_, err := p.product.WaitForProduct(ctx, portId, product.ProvisioningReady, opts)
+	if errors.Is(err, mega_err.ErrWaitTimeoutExceed) {
+		return false, mega_err.ErrPortProvisionTimeoutExceed
+	} else if err != nil {
+		return false, err
 	}
 
-	return false, errors.New(mega_err.ERR_PORT_PROVISION_TIMEOUT_EXCEED)
+	return true, nil
 }
@@ -18,7 +18,6 @@
 package port
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -208,16 +207,17 @@ func TestLAGPort(t *testing.T) {
 }
 
 func testCreatePort(port *Port, portType string, location types.Location) (string, error) {
-	var portId string
+	var portOrder types.OrderResult
 	var portErr error
 
 	logger.Debug("Buying Port:", portType)
 	if portType == types.LAG_PORT {
-		portId, portErr = port.BuyLAGPort("Buy Port (LAG) Test", 1, 10000, location.ID, location.Market, 2, true)
+		portOrder, portErr = port.BuyLAGPort("Buy Port (LAG) Test", 1, 10000, location.ID, location.Market, 2, true)
 	} else {
-		portId, portErr = port.BuySinglePort("Buy Port (Single) Test", 1, 10000, location.ID, location.Market, true)
+		portOrder, portErr = port.BuySinglePort("Buy Port (Single) Test", 1, 10000, location.ID, location.Market, true)
 	}
 
+	portId := portOrder.TechnicalServiceUID()
 	logger.Debugf("Port Purchased: %s", portId)
 	return portId, portErr
 }
@@ -277,7 +277,7 @@ func testLockPort(port *Port, portId string, t *testing.T) {
 	logger.Debug("Test lock of an already locked port.")
 	lockStatus, lockErr = port.LockPort(portId)
 	assert.True(t, lockStatus)
-	assert.Error(t, errors.New(mega_err.ERR_PORT_ALREADY_LOCKED), lockErr)
+	assert.ErrorIs(t, lockErr, mega_err.ErrPortAlreadyLocked)
 
 	logger.Debug("Unlocking Port now.")
 	unlockStatus, unlockErr := port.UnlockPort(portId)
@@ -287,5 +287,5 @@ func testLockPort(port *Port, portId string, t *testing.T) {
 	logger.Debug("Test unlocking of a port that doesn't have a lock.")
 	unlockStatus, unlockErr = port.UnlockPort(portId)
 	assert.True(t, unlockStatus)
-	assert.Error(t, errors.New(mega_err.ERR_PORT_NOT_LOCKED), unlockErr)
+	assert.ErrorIs(t, unlockErr, mega_err.ErrPortNotLocked)
 }
@@ -0,0 +1,96 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package port
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPortOrder(t *testing.T) {
+	body, err := buildPortOrder(types.BuyPortInput{
+		Name:                  "Port",
+		Term:                  12,
+		PortSpeed:             10000,
+		LocationID:            1,
+		Market:                "AU",
+		IsLAG:                 true,
+		LagCount:              2,
+		DiversityZone:         "red",
+		CostCentre:            "Networks",
+		PromoCode:             "PROMO",
+		ServiceLevelReference: "SLR-1",
+		Tags:                  map[string]string{"owner": "networks"},
+	})
+	assert.NoError(t, err)
+
+	var orders []types.PortOrder
+	assert.NoError(t, json.Unmarshal(body, &orders))
+	assert.Len(t, orders, 1)
+
+	order := orders[0]
+	assert.Equal(t, "MEGAPORT", order.ProductType)
+	assert.Equal(t, 2, order.LagPortCount)
+	assert.False(t, order.MarketplaceVisibility)
+	assert.Equal(t, "red", order.DiversityZone)
+	assert.Equal(t, "Networks", order.CostCentre)
+	assert.Equal(t, "PROMO", order.PromoCode)
+	assert.Equal(t, "SLR-1", order.ServiceLevelReference)
+	assert.Equal(t, types.Tags{"owner": "networks"}, order.AttributeTags)
+}
+
+func TestBuildPortOrderInvalid(t *testing.T) {
+	valid := portInput("Port", 1, 10000, 1, "AU", false, 0, true)
+
+	_, err := buildPortOrder(valid)
+	assert.NoError(t, err)
+
+	input := valid
+	input.Term = 6
+	_, err = buildPortOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+
+	input = valid
+	input.Name = ""
+	_, err = buildPortOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+
+	input = valid
+	input.IsLAG = true
+	input.LagCount = 9
+	_, err = buildPortOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+
+	input = valid
+	input.IsLAG = true
+	input.LagCount = 0
+	_, err = buildPortOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+	if validationErr, ok := mega_err.AsValidationError(err); assert.True(t, ok) {
+		assert.NotNil(t, validationErr.Field("lagPortCount"))
+	}
+
+	input = valid
+	input.DiversityZone = "green"
+	_, err = buildPortOrder(input)
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+}
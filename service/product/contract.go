@@ -0,0 +1,118 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
+)
+
+// ChangeProductTerm changes the contract term, in months, of a Port, MCR, MVE or VXC. The term must be one that
+// products can be ordered with, see validation.ValidTerm. It returns mega_err.ErrWrongProductTermChange for other
+// types of product and ErrProductInactive if the product has been cancelled or decommissioned.
+func (p *Product) ChangeProductTerm(productUID string, term int) (bool, error) {
+	return p.ChangeProductTermWithContext(context.Background(), productUID, term)
+}
+
+// ChangeProductTermWithContext is the same as ChangeProductTerm, using the supplied context for the API calls.
+func (p *Product) ChangeProductTermWithContext(ctx context.Context, productUID string, term int) (bool, error) {
+	update := types.ProductTermUpdate{Term: term}
+	if err := validation.Validate(update); err != nil {
+		return false, err
+	}
+
+	product, err := p.GetProductWithContext(ctx, productUID)
+	if err != nil {
+		return false, err
+	}
+
+	productType := strings.ToLower(product.GetType())
+	switch productType {
+	case types.PRODUCT_MEGAPORT, types.PRODUCT_MCR, types.PRODUCT_MVE, types.PRODUCT_VXC:
+	default:
+		return false, fmt.Errorf("%w: %s is a %s", mega_err.ErrWrongProductTermChange, productUID, product.GetType())
+	}
+
+	status := product.GetProvisioningStatus()
+	if strings.EqualFold(status, types.STATUS_DECOMMISSIONED) || strings.EqualFold(status, types.STATUS_CANCELLED) {
+		return false, fmt.Errorf("%w: %s is %s", mega_err.ErrProductInactive, productUID, status)
+	}
+
+	body, marshalErr := json.Marshal(update)
+	if marshalErr != nil {
+		return false, marshalErr
+	}
+
+	url := fmt.Sprintf("/v2/product/%s/%s", productType, productUID)
+	response, err := p.Config.MakeAPICallWithContext(ctx, "PUT", url, body)
+	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return false, parsedError
+	}
+	defer response.Body.Close()
+
+	return true, nil
+}
+
+// ContractExpiry describes a product whose contract term is coming to an end.
+type ContractExpiry struct {
+	Product types.Product
+
+	// EndDate is when the contract term ends.
+	EndDate time.Time
+
+	// DaysRemaining is the number of whole days until EndDate.
+	DaysRemaining int
+}
+
+// ExpiringContracts returns the active products, including VXCs, whose contracts end within the given number of days,
+// soonest first. Products without a contract end date are not included.
+func (p *Product) ExpiringContracts(days int) ([]ContractExpiry, error) {
+	return p.ExpiringContractsWithContext(context.Background(), days)
+}
+
+// ExpiringContractsWithContext is the same as ExpiringContracts, using the supplied context for the API call.
+func (p *Product) ExpiringContractsWithContext(ctx context.Context, days int) ([]ContractExpiry, error) {
+	now := time.Now()
+
+	query := Query().Active().ContractEndsAfter(now).ContractEndsBefore(now.AddDate(0, 0, days))
+	products, err := p.QueryProductsWithContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	expiring := make([]ContractExpiry, 0, len(products))
+	for _, product := range products {
+		end := product.GetContractEndDate().Time
+		expiring = append(expiring, ContractExpiry{
+			Product:       product,
+			EndDate:       end,
+			DaysRemaining: int(end.Sub(now) / (24 * time.Hour)),
+		})
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool { return expiring[i].EndDate.Before(expiring[j].EndDate) })
+
+	return expiring, nil
+}
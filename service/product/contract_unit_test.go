@@ -0,0 +1,109 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeProductTerm(t *testing.T) {
+	products := map[string]string{
+		"port-1": `{"productUid":"port-1","productType":"MEGAPORT","provisioningStatus":"LIVE"}`,
+		"mcr-1":  `{"productUid":"mcr-1","productType":"MCR2","provisioningStatus":"LIVE"}`,
+		"ix-1":   `{"productUid":"ix-1","productType":"IX","provisioningStatus":"LIVE"}`,
+		"vxc-1":  `{"productUid":"vxc-1","productType":"VXC","provisioningStatus":"CANCELLED"}`,
+	}
+	var updates []string
+
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		case r.Method == "GET" && len(path) == 3 && products[path[2]] != "":
+			w.Write([]byte(`{"message":"ok","terms":"","data":` + products[path[2]] + `}`))
+		case r.Method == "PUT" && len(path) == 4:
+			body, _ := io.ReadAll(r.Body)
+			updates = append(updates, fmt.Sprintf("%s %s", r.URL.Path, body))
+			w.Write([]byte(`{"message":"ok","terms":"","data":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found","terms":"","data":""}`))
+		}
+	})
+	defer server.Close()
+
+	changed, err := p.ChangeProductTerm("port-1", 24)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	_, err = p.ChangeProductTerm("mcr-1", 36)
+	assert.NoError(t, err)
+
+	_, err = p.ChangeProductTerm("port-1", 6)
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+
+	_, err = p.ChangeProductTerm("ix-1", 12)
+	assert.ErrorIs(t, err, mega_err.ErrWrongProductTermChange)
+
+	_, err = p.ChangeProductTerm("vxc-1", 12)
+	assert.ErrorIs(t, err, mega_err.ErrProductInactive)
+
+	_, err = p.ChangeProductTerm("missing", 12)
+	assert.True(t, mega_err.IsNotFound(err))
+
+	assert.Equal(t, []string{`/v2/product/megaport/port-1 {"term":24}`, `/v2/product/mcr2/mcr-1 {"term":36}`}, updates)
+}
+
+func TestExpiringContracts(t *testing.T) {
+	inDays := func(days int) int64 {
+		return time.Now().AddDate(0, 0, days).Add(time.Hour).UnixMilli()
+	}
+
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf(`{"message":"ok","terms":"","data":[
+			{"productUid":"port-1","productType":"MEGAPORT","provisioningStatus":"LIVE","contractEndDate":%d,
+				"associatedVxcs":[{"productUid":"vxc-1","productType":"VXC","provisioningStatus":"LIVE","contractEndDate":%d}]},
+			{"productUid":"port-2","productType":"MEGAPORT","provisioningStatus":"LIVE","contractEndDate":%d},
+			{"productUid":"port-3","productType":"MEGAPORT","provisioningStatus":"LIVE","contractEndDate":%d},
+			{"productUid":"port-4","productType":"MEGAPORT","provisioningStatus":"CANCELLED","contractEndDate":%d},
+			{"productUid":"mcr-1","productType":"MCR2","provisioningStatus":"LIVE"}
+		]}`, inDays(30), inDays(5), inDays(120), inDays(-10), inDays(5))))
+	})
+	defer server.Close()
+
+	expiring, err := p.ExpiringContracts(60)
+	assert.NoError(t, err)
+	if assert.Len(t, expiring, 2) {
+		assert.Equal(t, "vxc-1", expiring[0].Product.GetUID())
+		assert.Equal(t, 5, expiring[0].DaysRemaining)
+		assert.Equal(t, "port-1", expiring[1].Product.GetUID())
+		assert.Equal(t, 30, expiring[1].DaysRemaining)
+	}
+
+	expiring, err = p.ExpiringContracts(1)
+	assert.NoError(t, err)
+	assert.Empty(t, expiring)
+}
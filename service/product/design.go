@@ -0,0 +1,287 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
+)
+
+// NetworkDesign assembles Ports, MCRs, MVEs and VXCs into a single order, so that they are bought together or not at
+// all. Build one with NewNetworkDesign, e.g.
+//
+//	design := product.NewNetworkDesign()
+//	port := design.AddPort(types.PortOrder{Name: "Port", Term: 12, PortSpeed: 10000, LocationID: 1, Market: "AU"})
+//	mcr := design.AddMCR(types.MCROrder{Name: "MCR", Term: 12, PortSpeed: 1000, LocationID: 2})
+//	design.AddVXC(port, mcr, types.VXCOrderConfiguration{Name: "Port to MCR", RateLimit: 1000})
+//	design.AddAWSVXC(mcr, awsVXC)
+//	result, err := p.BuyNetworkDesign(design)
+//
+// VXCs may connect products in the design to each other or to existing products, referenced with ExistingProduct.
+// Errors adding items are returned when the design is built.
+type NetworkDesign struct {
+	items []*designItem
+
+	// err is the first error from adding items to the design, returned when it is built.
+	err error
+}
+
+// DesignRef refers to an end of a VXC in a network design: either an item added to the design or an existing product.
+type DesignRef struct {
+	uid  string
+	item *designItem
+}
+
+// ExistingProduct refers to a product that has already been bought, for use as the end of a VXC in a network design.
+func ExistingProduct(uid string) DesignRef {
+	return DesignRef{uid: uid}
+}
+
+// UID returns the UID the design uses for the product. For items added to the design this is a temporary UID that the
+// API replaces with the UID of the product it creates.
+func (r DesignRef) UID() string {
+	if r.item != nil {
+		return r.item.uid
+	}
+	return r.uid
+}
+
+// designItem is an entry of the order: a product to buy, or an existing product that new VXCs are attached to.
+type designItem struct {
+	uid string
+
+	// product is the order for a new product, or nil for an existing product.
+	product interface{}
+
+	vxcs []interface{}
+}
+
+func (i *designItem) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+
+	if i.product != nil {
+		productJSON, err := json.Marshal(i.product)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(productJSON, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	fields["productUid"] = i.uid
+	if len(i.vxcs) > 0 {
+		fields["associatedVxcs"] = i.vxcs
+	}
+
+	return json.Marshal(fields)
+}
+
+// NewNetworkDesign returns an empty network design.
+func NewNetworkDesign() *NetworkDesign {
+	return &NetworkDesign{}
+}
+
+// AddPort adds a Port to the design. The product type and creation date are filled in if they are not set.
+func (d *NetworkDesign) AddPort(order types.PortOrder) DesignRef {
+	if order.ProductType == "" {
+		order.ProductType = "MEGAPORT"
+	}
+	if order.CreateDate.IsZero() {
+		order.CreateDate = types.NewTimestamp(time.Now())
+	}
+	return d.addProduct(order)
+}
+
+// AddMCR adds an MCR to the design. The product type is filled in if it is not set.
+func (d *NetworkDesign) AddMCR(order types.MCROrder) DesignRef {
+	if order.Type == "" {
+		order.Type = "MCR2"
+	}
+	return d.addProduct(order)
+}
+
+// AddMVE adds an MVE to the design. The product type and a default vNIC are filled in if they are not set.
+func (d *NetworkDesign) AddMVE(order types.MVEOrderConfig) DesignRef {
+	if order.ProductType == "" {
+		order.ProductType = strings.ToUpper(types.PRODUCT_MVE)
+	}
+	if len(order.NetworkInterfaces) == 0 {
+		order.NetworkInterfaces = []*types.MVENetworkInterface{{Description: "Data Plane"}}
+	}
+	return d.addProduct(order)
+}
+
+// AddVXC adds a VXC between aEnd and bEnd to the design. The B-End product UID of the configuration is set from bEnd.
+func (d *NetworkDesign) AddVXC(aEnd DesignRef, bEnd DesignRef, vxc types.VXCOrderConfiguration) *NetworkDesign {
+	if !d.check(bEnd) {
+		return d
+	}
+	vxc.BEnd.ProductUID = bEnd.UID()
+	return d.addVXC(aEnd, vxc)
+}
+
+// AddAWSVXC adds a VXC from aEnd to AWS to the design.
+func (d *NetworkDesign) AddAWSVXC(aEnd DesignRef, vxc types.AWSVXCOrderConfiguration) *NetworkDesign {
+	return d.addVXC(aEnd, vxc)
+}
+
+// AddPartnerVXC adds a VXC from aEnd to a partner port, such as Azure ExpressRoute or Google Interconnect, to the
+// design.
+func (d *NetworkDesign) AddPartnerVXC(aEnd DesignRef, vxc types.PartnerOrderContents) *NetworkDesign {
+	return d.addVXC(aEnd, vxc)
+}
+
+// Build returns the order for the design, in the form accepted by ExecuteOrder and ValidateOrder. Every item is
+// checked with the validation package first, and all problems found are returned in a *mega_err.ValidationError.
+func (d *NetworkDesign) Build() ([]byte, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.items) == 0 {
+		return nil, mega_err.ErrEmptyNetworkDesign
+	}
+
+	var errs validation.Errors
+	for i, item := range d.items {
+		if item.product != nil {
+			errs.Add(fmt.Sprintf("[%d]", i), validation.Validate(item.product))
+		}
+		for j, vxc := range item.vxcs {
+			errs.Add(fmt.Sprintf("[%d].associatedVxcs[%d]", i, j), validation.Validate(vxc))
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(d.items)
+}
+
+func (d *NetworkDesign) addProduct(order interface{}) DesignRef {
+	uid, err := newDesignUID()
+	if err != nil {
+		d.fail(err)
+		return DesignRef{}
+	}
+
+	item := &designItem{uid: uid, product: order}
+	d.items = append(d.items, item)
+
+	return DesignRef{item: item}
+}
+
+func (d *NetworkDesign) addVXC(aEnd DesignRef, vxc interface{}) *NetworkDesign {
+	if !d.check(aEnd) {
+		return d
+	}
+
+	item := aEnd.item
+	if item == nil {
+		// VXCs on the same existing product are grouped into one entry.
+		for _, existing := range d.items {
+			if existing.product == nil && existing.uid == aEnd.uid {
+				item = existing
+				break
+			}
+		}
+		if item == nil {
+			item = &designItem{uid: aEnd.uid}
+			d.items = append(d.items, item)
+		}
+	}
+
+	item.vxcs = append(item.vxcs, vxc)
+	return d
+}
+
+// check records an error if ref refers to neither an existing product nor an item of this design.
+func (d *NetworkDesign) check(ref DesignRef) bool {
+	if ref.item == nil {
+		if ref.uid == "" {
+			d.fail(fmt.Errorf("%w: no product UID given", mega_err.ErrUnknownDesignItem))
+			return false
+		}
+		return true
+	}
+
+	for _, item := range d.items {
+		if item == ref.item {
+			return true
+		}
+	}
+
+	d.fail(fmt.Errorf("%w: %s", mega_err.ErrUnknownDesignItem, ref.item.uid))
+	return false
+}
+
+func (d *NetworkDesign) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// newDesignUID returns a random UID in the same format as product UIDs, identifying a new product in a design.
+func newDesignUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// ValidateNetworkDesign checks a network design with the API without buying it, returning the price of each item.
+// See ValidateOrder.
+func (p *Product) ValidateNetworkDesign(design *NetworkDesign) (types.OrderQuote, error) {
+	return p.ValidateNetworkDesignWithContext(context.Background(), design)
+}
+
+// ValidateNetworkDesignWithContext is the same as ValidateNetworkDesign, using the supplied context for the API call.
+func (p *Product) ValidateNetworkDesignWithContext(ctx context.Context, design *NetworkDesign) (types.OrderQuote, error) {
+	requestBody, err := design.Build()
+	if err != nil {
+		return types.OrderQuote{}, err
+	}
+
+	return p.ValidateOrderWithContext(ctx, &requestBody)
+}
+
+// BuyNetworkDesign buys every item of a network design in a single order, so that either all of them are created or
+// none are. The result holds a confirmation for each service created.
+func (p *Product) BuyNetworkDesign(design *NetworkDesign) (types.OrderResult, error) {
+	return p.BuyNetworkDesignWithContext(context.Background(), design)
+}
+
+// BuyNetworkDesignWithContext is the same as BuyNetworkDesign, using the supplied context for the API call.
+func (p *Product) BuyNetworkDesignWithContext(ctx context.Context, design *NetworkDesign) (types.OrderResult, error) {
+	requestBody, err := design.Build()
+	if err != nil {
+		return types.OrderResult{}, err
+	}
+
+	return p.ExecuteOrderWithContext(ctx, &requestBody)
+}
@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDesignBuild(t *testing.T) {
+	design := NewNetworkDesign()
+	port := design.AddPort(types.PortOrder{Name: "Port", Term: 12, PortSpeed: 10000, LocationID: 1, Market: "AU"})
+	mcr := design.AddMCR(types.MCROrder{Name: "MCR", Term: 12, PortSpeed: 1000, LocationID: 2})
+	design.AddVXC(port, mcr, types.VXCOrderConfiguration{Name: "Port to MCR", RateLimit: 1000})
+	design.AddAWSVXC(mcr, types.AWSVXCOrderConfiguration{Name: "MCR to AWS", RateLimit: 500,
+		BEnd: types.AWSVXCOrderBEndConfiguration{ProductUID: "aws-port",
+			PartnerConfig: types.AWSVXCOrderBEndPartnerConfig{ConnectType: "AWSHC", Type: "private", OwnerAccount: "123456789012"}}})
+	design.AddVXC(ExistingProduct("existing-port"), port, types.VXCOrderConfiguration{Name: "Existing to Port", RateLimit: 100})
+	design.AddVXC(ExistingProduct("existing-port"), mcr, types.VXCOrderConfiguration{Name: "Existing to MCR", RateLimit: 100})
+
+	assert.True(t, shared.IsGuid(port.UID()))
+	assert.NotEqual(t, port.UID(), mcr.UID())
+
+	body, err := design.Build()
+	assert.NoError(t, err)
+
+	var items []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &items))
+	assert.Len(t, items, 3)
+
+	assert.Equal(t, port.UID(), items[0]["productUid"])
+	assert.Equal(t, "MEGAPORT", items[0]["productType"])
+	portVXCs := items[0]["associatedVxcs"].([]interface{})
+	assert.Len(t, portVXCs, 1)
+	assert.Equal(t, mcr.UID(), portVXCs[0].(map[string]interface{})["bEnd"].(map[string]interface{})["productUid"])
+
+	assert.Equal(t, "MCR2", items[1]["productType"])
+	assert.Len(t, items[1]["associatedVxcs"], 1)
+
+	assert.Equal(t, "existing-port", items[2]["productUid"])
+	assert.Len(t, items[2]["associatedVxcs"], 2)
+	assert.NotContains(t, items[2], "productType")
+}
+
+func TestNetworkDesignErrors(t *testing.T) {
+	_, err := NewNetworkDesign().Build()
+	assert.ErrorIs(t, err, mega_err.ErrEmptyNetworkDesign)
+
+	design := NewNetworkDesign()
+	design.AddPort(types.PortOrder{Name: "Port", Term: 6, PortSpeed: 10000, LocationID: 1})
+	_, err = design.Build()
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+
+	design = NewNetworkDesign()
+	design.AddMCR(types.MCROrder{Name: "MCR", Term: 1, PortSpeed: 3000, LocationID: 1})
+	_, err = design.Build()
+	assert.ErrorIs(t, err, mega_err.ErrMCRInvalidPortSpeed)
+
+	design = NewNetworkDesign()
+	design.AddPort(types.PortOrder{Term: 1, PortSpeed: 10000, LocationID: 1})
+	design.AddMCR(types.MCROrder{Name: "MCR", Term: 5, PortSpeed: 1000, LocationID: 1})
+	_, err = design.Build()
+	validationErr, ok := mega_err.AsValidationError(err)
+	assert.True(t, ok)
+	assert.Len(t, validationErr.Errors, 2)
+	assert.NotNil(t, validationErr.Field("[0].productName"))
+	assert.ErrorIs(t, validationErr.Field("[1].term"), mega_err.ErrTermNotValid)
+
+	other := NewNetworkDesign()
+	otherPort := other.AddPort(types.PortOrder{Name: "Port", Term: 1, PortSpeed: 10000, LocationID: 1})
+	design = NewNetworkDesign()
+	port := design.AddPort(types.PortOrder{Name: "Port", Term: 1, PortSpeed: 10000, LocationID: 1})
+	design.AddVXC(port, otherPort, types.VXCOrderConfiguration{Name: "VXC", RateLimit: 100})
+	_, err = design.Build()
+	assert.ErrorIs(t, err, mega_err.ErrUnknownDesignItem)
+}
+
+func TestBuyNetworkDesign(t *testing.T) {
+	design := NewNetworkDesign()
+	port := design.AddPort(types.PortOrder{Name: "Port", Term: 1, PortSpeed: 10000, LocationID: 1})
+	design.AddVXC(port, ExistingProduct("existing-port"), types.VXCOrderConfiguration{Name: "VXC", RateLimit: 100})
+
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/networkdesign/buy", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		expected, _ := design.Build()
+		assert.JSONEq(t, string(expected), string(body))
+
+		w.Write([]byte(`{"message":"Created","terms":"","data":[
+			{"technicalServiceUid":"port-uid","productType":"MEGAPORT"},
+			{"vxcJTechnicalServiceUid":"vxc-uid","productType":"VXC"}]}`))
+	})
+	defer server.Close()
+
+	result, err := p.BuyNetworkDesign(design)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"port-uid", "vxc-uid"}, result.UIDs())
+
+	_, err = p.BuyNetworkDesign(NewNetworkDesign())
+	assert.ErrorIs(t, err, mega_err.ErrEmptyNetworkDesign)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// ListProducts returns every product in the account. Ports, MCRs and MVEs are returned as *types.Port, *types.MCR
+// and *types.MVE, with their VXCs and IXs in AssociatedVXCs and AssociatedIXs; see FlattenProducts. Products of
+// types not modelled by this library are returned as *types.UnknownProduct.
+func (p *Product) ListProducts() ([]types.Product, error) {
+	return p.ListProductsWithContext(context.Background())
+}
+
+// ListProductsWithContext is the same as ListProducts, using the supplied context for the API call.
+func (p *Product) ListProductsWithContext(ctx context.Context) ([]types.Product, error) {
+	response, err := p.Config.MakeAPICallWithContext(ctx, "GET", "/v2/products", nil)
+	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+	defer response.Body.Close()
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	parsed := types.ProductListResponse{}
+	if unmarshalErr := json.Unmarshal(body, &parsed); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	products := make([]types.Product, 0, len(parsed.Data))
+	for _, item := range parsed.Data {
+		products = append(products, item.Product)
+	}
+
+	return products, nil
+}
+
+// GetProduct returns the product with the given UID, decoded according to its type as for ListProducts.
+func (p *Product) GetProduct(productUID string) (types.Product, error) {
+	return p.GetProductWithContext(context.Background(), productUID)
+}
+
+// GetProductWithContext is the same as GetProduct, using the supplied context for the API call.
+func (p *Product) GetProductWithContext(ctx context.Context, productUID string) (types.Product, error) {
+	response, err := p.Config.MakeAPICallWithContext(ctx, "GET", "/v2/product/"+productUID, nil)
+	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+	defer response.Body.Close()
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	parsed := types.ProductResponse{}
+	if unmarshalErr := json.Unmarshal(body, &parsed); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return parsed.Data.Product, nil
+}
+
+// FlattenProducts returns the products followed by their associated VXCs and IXs. A VXC connecting two of the
+// products is only included once.
+func FlattenProducts(products []types.Product) []types.Product {
+	flattened := make([]types.Product, 0, len(products))
+	seen := map[string]bool{}
+
+	add := func(product types.Product) {
+		if uid := product.GetUID(); uid != "" {
+			if seen[uid] {
+				return
+			}
+			seen[uid] = true
+		}
+		flattened = append(flattened, product)
+	}
+
+	for _, product := range products {
+		add(product)
+	}
+
+	for _, product := range products {
+		vxcs := product.GetAssociatedVXCs()
+		for i := range vxcs {
+			add(&vxcs[i])
+		}
+
+		ixs := product.GetAssociatedIXs()
+		for i := range ixs {
+			add(&ixs[i])
+		}
+	}
+
+	return flattened
+}
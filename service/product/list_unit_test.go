@@ -0,0 +1,121 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_PRODUCTS_RESPONSE = `{"message":"ok","terms":"","data":[
+	{"productUid":"port-1","productName":"Port","productType":"MEGAPORT","provisioningStatus":"LIVE","locationId":1,"portSpeed":10000,
+	 "associatedVxcs":[{"productUid":"vxc-1","productName":"Port to MCR","productType":"VXC","provisioningStatus":"LIVE","rateLimit":100,
+	                    "aEnd":{"productUid":"port-1","locationId":1},"bEnd":{"productUid":"mcr-1","locationId":2}}],
+	 "associatedIxs":[{"productUid":"ix-1","productName":"IX","productType":"IX","provisioningStatus":"LIVE","locationId":1,"vlan":100,"asn":65000}]},
+	{"productUid":"mcr-1","productName":"MCR","productType":"MCR2","provisioningStatus":"CONFIGURED","locationId":2,
+	 "resources":{"virtual_router":{"mcrAsn":133937}},
+	 "associatedVxcs":[{"productUid":"vxc-1","productName":"Port to MCR","productType":"VXC","provisioningStatus":"LIVE",
+	                    "aEnd":{"productUid":"port-1","locationId":1},"bEnd":{"productUid":"mcr-1","locationId":2}}]},
+	{"productUid":"mve-1","productName":"MVE","productType":"MVE","provisioningStatus":"LIVE","locationId":3,"vendor":"Cisco","vnics":[{"description":"Data","vlan":0}]},
+	{"productUid":"new-1","productName":"Future product","productType":"SATELLITE","provisioningStatus":"LIVE","locationId":4}
+]}`
+
+func newTestProduct(handler http.HandlerFunc) (*httptest.Server, *Product) {
+	server := httptest.NewServer(handler)
+
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+
+	return server, New(&config.Config{
+		Log:      logger,
+		Endpoint: server.URL,
+		Client:   server.Client(),
+	})
+}
+
+func TestListProducts(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/products", r.URL.Path)
+		w.Write([]byte(TEST_PRODUCTS_RESPONSE))
+	})
+	defer server.Close()
+
+	products, err := p.ListProducts()
+	assert.NoError(t, err)
+	assert.Len(t, products, 4)
+
+	port, ok := products[0].(*types.Port)
+	assert.True(t, ok)
+	assert.Equal(t, 10000, port.PortSpeed)
+	assert.Len(t, port.AssociatedVXCs, 1)
+	assert.Equal(t, 100, port.AssociatedVXCs[0].RateLimit)
+	assert.Equal(t, "mcr-1", port.AssociatedVXCs[0].BEndConfiguration.UID)
+	assert.Len(t, port.AssociatedIXs, 1)
+	assert.Equal(t, 65000, port.AssociatedIXs[0].ASN)
+
+	mcr, ok := products[1].(*types.MCR)
+	assert.True(t, ok)
+	assert.Equal(t, 133937, mcr.Resources.VirtualRouter.ASN)
+
+	mve, ok := products[2].(*types.MVE)
+	assert.True(t, ok)
+	assert.Equal(t, "Cisco", mve.Vendor)
+
+	unknown, ok := products[3].(*types.UnknownProduct)
+	assert.True(t, ok)
+	assert.Equal(t, "SATELLITE", unknown.GetType())
+	assert.Equal(t, 4, unknown.GetLocationID())
+	assert.Contains(t, string(unknown.Raw), "Future product")
+}
+
+func TestFlattenProducts(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(TEST_PRODUCTS_RESPONSE))
+	})
+	defer server.Close()
+
+	products, err := p.ListProducts()
+	assert.NoError(t, err)
+
+	var uids []string
+	for _, product := range FlattenProducts(products) {
+		uids = append(uids, product.GetUID())
+	}
+
+	assert.Equal(t, []string{"port-1", "mcr-1", "mve-1", "new-1", "vxc-1", "ix-1"}, uids)
+}
+
+func TestGetProduct(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/product/vxc-1", r.URL.Path)
+		w.Write([]byte(`{"message":"ok","terms":"","data":{"productUid":"vxc-1","productType":"VXC","aEnd":{"locationId":7}}}`))
+	})
+	defer server.Close()
+
+	product, err := p.GetProduct("vxc-1")
+	assert.NoError(t, err)
+
+	vxc, ok := product.(*types.VXC)
+	assert.True(t, ok)
+	assert.Equal(t, 7, vxc.GetLocationID())
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// LockProduct locks a product of any type against changes. It returns mega_err.ErrProductAlreadyLocked if the product
+// is already locked, ErrProductAdminLocked if Megaport has locked it and ErrProductInactive if it has been cancelled
+// or decommissioned.
+func (p *Product) LockProduct(productUID string) (bool, error) {
+	return p.LockProductWithContext(context.Background(), productUID)
+}
+
+// LockProductWithContext is the same as LockProduct, using the supplied context for the API calls.
+func (p *Product) LockProductWithContext(ctx context.Context, productUID string) (bool, error) {
+	return p.setProductLock(ctx, productUID, true)
+}
+
+// UnlockProduct unlocks a locked product of any type. It returns mega_err.ErrProductNotLocked if the product is not
+// locked, ErrProductAdminLocked if Megaport has locked it and ErrProductInactive if it has been cancelled or
+// decommissioned.
+func (p *Product) UnlockProduct(productUID string) (bool, error) {
+	return p.UnlockProductWithContext(context.Background(), productUID)
+}
+
+// UnlockProductWithContext is the same as UnlockProduct, using the supplied context for the API calls.
+func (p *Product) UnlockProductWithContext(ctx context.Context, productUID string) (bool, error) {
+	return p.setProductLock(ctx, productUID, false)
+}
+
+func (p *Product) setProductLock(ctx context.Context, productUID string, lock bool) (bool, error) {
+	product, err := p.GetProductWithContext(ctx, productUID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := lockStateError(product, lock); err != nil {
+		return false, err
+	}
+
+	return p.ManageProductLockWithContext(ctx, productUID, lock)
+}
+
+// lockStateError returns the reason product cannot be locked or unlocked, or nil if it can.
+func lockStateError(product types.Product, lock bool) error {
+	uid := product.GetUID()
+	status := product.GetProvisioningStatus()
+
+	switch {
+	case strings.EqualFold(status, types.STATUS_DECOMMISSIONED) || strings.EqualFold(status, types.STATUS_CANCELLED):
+		return fmt.Errorf("%w: %s is %s", mega_err.ErrProductInactive, uid, status)
+	case product.IsAdminLocked():
+		return fmt.Errorf("%w: %s", mega_err.ErrProductAdminLocked, uid)
+	case lock && product.IsLocked():
+		return fmt.Errorf("%w: %s", mega_err.ErrProductAlreadyLocked, uid)
+	case !lock && !product.IsLocked():
+		return fmt.Errorf("%w: %s", mega_err.ErrProductNotLocked, uid)
+	}
+
+	return nil
+}
+
+// BulkLockResult reports what LockProducts or UnlockProducts did to each product matched by the query.
+type BulkLockResult struct {
+	// Changed lists the UIDs of the products that were locked or unlocked.
+	Changed []string
+
+	// Skipped lists the UIDs of the products that were already in the requested state.
+	Skipped []string
+
+	// Failed holds the error for each product that could not be changed, by UID.
+	Failed map[string]error
+}
+
+// Err returns the errors of every failed product joined together, or nil if none failed.
+func (r BulkLockResult) Err() error {
+	errs := make([]error, 0, len(r.Failed))
+	for _, err := range r.Failed {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// LockProducts locks every product matched by query, e.g.
+//
+//	result, err := p.LockProducts(product.Query().Active().Tag("environment", "production"))
+//
+// Products that are already locked are skipped. Failures to lock individual products are reported in the result; an
+// error is only returned if the products could not be listed or the context is done.
+func (p *Product) LockProducts(query *ProductQuery) (BulkLockResult, error) {
+	return p.LockProductsWithContext(context.Background(), query)
+}
+
+// LockProductsWithContext is the same as LockProducts, using the supplied context for the API calls.
+func (p *Product) LockProductsWithContext(ctx context.Context, query *ProductQuery) (BulkLockResult, error) {
+	return p.setProductsLock(ctx, query, true)
+}
+
+// UnlockProducts unlocks every product matched by query. Products that are not locked are skipped. See LockProducts.
+func (p *Product) UnlockProducts(query *ProductQuery) (BulkLockResult, error) {
+	return p.UnlockProductsWithContext(context.Background(), query)
+}
+
+// UnlockProductsWithContext is the same as UnlockProducts, using the supplied context for the API calls.
+func (p *Product) UnlockProductsWithContext(ctx context.Context, query *ProductQuery) (BulkLockResult, error) {
+	return p.setProductsLock(ctx, query, false)
+}
+
+func (p *Product) setProductsLock(ctx context.Context, query *ProductQuery, lock bool) (BulkLockResult, error) {
+	result := BulkLockResult{Failed: map[string]error{}}
+
+	products, err := p.QueryProductsWithContext(ctx, query)
+	if err != nil {
+		return result, err
+	}
+
+	for _, product := range products {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		uid := product.GetUID()
+
+		err := lockStateError(product, lock)
+		if errors.Is(err, mega_err.ErrProductAlreadyLocked) || errors.Is(err, mega_err.ErrProductNotLocked) {
+			result.Skipped = append(result.Skipped, uid)
+			continue
+		} else if err != nil {
+			result.Failed[uid] = err
+			continue
+		}
+
+		if _, err := p.ManageProductLockWithContext(ctx, uid, lock); err != nil {
+			result.Failed[uid] = err
+			continue
+		}
+		result.Changed = append(result.Changed, uid)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,126 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TEST_LOCK_PRODUCTS holds a product in each lock state, by UID.
+var TEST_LOCK_PRODUCTS = map[string]string{
+	"port-1": `{"productUid":"port-1","productName":"Port","productType":"MEGAPORT","provisioningStatus":"LIVE"}`,
+	"mcr-1":  `{"productUid":"mcr-1","productName":"MCR","productType":"MCR2","provisioningStatus":"LIVE","locked":true}`,
+	"mve-1":  `{"productUid":"mve-1","productName":"MVE","productType":"MVE","provisioningStatus":"LIVE","adminLocked":true}`,
+	"vxc-1":  `{"productUid":"vxc-1","productName":"VXC","productType":"VXC","provisioningStatus":"DECOMMISSIONED"}`,
+}
+
+// newLockTestProduct serves TEST_LOCK_PRODUCTS and records the lock calls made, as "METHOD uid".
+func newLockTestProduct(t *testing.T) (*Product, func() []string, func()) {
+	var mu sync.Mutex
+	var calls []string
+
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		case r.URL.Path == "/v2/products":
+			items := []string{}
+			for _, uid := range []string{"port-1", "mcr-1", "mve-1", "vxc-1"} {
+				items = append(items, TEST_LOCK_PRODUCTS[uid])
+			}
+			w.Write([]byte(`{"message":"ok","terms":"","data":[` + strings.Join(items, ",") + `]}`))
+		case len(path) == 4 && path[3] == "lock":
+			mu.Lock()
+			calls = append(calls, r.Method+" "+path[2])
+			mu.Unlock()
+			w.Write([]byte(`{"message":"ok","terms":"","data":{}}`))
+		case len(path) == 3 && TEST_LOCK_PRODUCTS[path[2]] != "":
+			w.Write([]byte(`{"message":"ok","terms":"","data":` + TEST_LOCK_PRODUCTS[path[2]] + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found","terms":"","data":""}`))
+		}
+	})
+
+	recorded := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+
+	return p, recorded, server.Close
+}
+
+func TestLockProduct(t *testing.T) {
+	p, calls, closeServer := newLockTestProduct(t)
+	defer closeServer()
+
+	locked, err := p.LockProduct("port-1")
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	_, err = p.LockProduct("mcr-1")
+	assert.ErrorIs(t, err, mega_err.ErrProductAlreadyLocked)
+
+	_, err = p.LockProduct("mve-1")
+	assert.ErrorIs(t, err, mega_err.ErrProductAdminLocked)
+
+	_, err = p.LockProduct("vxc-1")
+	assert.ErrorIs(t, err, mega_err.ErrProductInactive)
+
+	_, err = p.LockProduct("missing")
+	assert.True(t, mega_err.IsNotFound(err))
+
+	unlocked, err := p.UnlockProduct("mcr-1")
+	assert.NoError(t, err)
+	assert.True(t, unlocked)
+
+	_, err = p.UnlockProduct("port-1")
+	assert.ErrorIs(t, err, mega_err.ErrProductNotLocked)
+
+	assert.Equal(t, []string{"POST port-1", "DELETE mcr-1"}, calls())
+}
+
+func TestLockProducts(t *testing.T) {
+	p, calls, closeServer := newLockTestProduct(t)
+	defer closeServer()
+
+	result, err := p.LockProducts(Query())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"port-1"}, result.Changed)
+	assert.Equal(t, []string{"mcr-1"}, result.Skipped)
+	assert.Len(t, result.Failed, 2)
+	assert.ErrorIs(t, result.Failed["mve-1"], mega_err.ErrProductAdminLocked)
+	assert.ErrorIs(t, result.Failed["vxc-1"], mega_err.ErrProductInactive)
+	assert.ErrorIs(t, result.Err(), mega_err.ErrProductInactive)
+	assert.Equal(t, []string{"POST port-1"}, calls())
+
+	result, err = p.UnlockProducts(Query().Active().Type(types.PRODUCT_MCR))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mcr-1"}, result.Changed)
+	assert.Empty(t, result.Failed)
+	assert.NoError(t, result.Err())
+	assert.Equal(t, []string{"POST port-1", "DELETE mcr-1"}, calls())
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// ActionLogFilter selects entries of a product's action log. The zero value selects every entry.
+type ActionLogFilter struct {
+	// From, if set, excludes entries before this time.
+	From time.Time
+
+	// To, if set, excludes entries at or after this time.
+	To time.Time
+
+	// ActionTypes, if set, selects only entries of these action types, compared case-insensitively.
+	ActionTypes []string
+}
+
+// Match reports whether the filter selects the entry.
+func (f ActionLogFilter) Match(entry types.ProductActionLog) bool {
+	at := entry.CreateDate
+	if !f.From.IsZero() && at.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !at.Before(f.To) {
+		return false
+	}
+
+	if len(f.ActionTypes) == 0 {
+		return true
+	}
+	for _, actionType := range f.ActionTypes {
+		if strings.EqualFold(actionType, entry.ActionType) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProductActionLogs returns the action log of a product of any type, selected by filter, in the order returned by
+// the API. Each entry records a change to the product, when it was made and by whom, e.g.
+//
+//	logs, err := p.GetProductActionLogs(uid, product.ActionLogFilter{From: time.Now().Add(-24 * time.Hour)})
+func (p *Product) GetProductActionLogs(productUID string, filter ActionLogFilter) ([]types.ProductActionLog, error) {
+	return p.GetProductActionLogsWithContext(context.Background(), productUID, filter)
+}
+
+// GetProductActionLogsWithContext is the same as GetProductActionLogs, using the supplied context for the API call.
+func (p *Product) GetProductActionLogsWithContext(ctx context.Context, productUID string, filter ActionLogFilter) ([]types.ProductActionLog, error) {
+	url := fmt.Sprintf("/v2/product/%s/logs", productUID)
+	response, err := p.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
+	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+	defer response.Body.Close()
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	parsed := types.ProductActionLogResponse{}
+	if unmarshalErr := json.Unmarshal(body, &parsed); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	logs := make([]types.ProductActionLog, 0, len(parsed.Data))
+	for _, entry := range parsed.Data {
+		if filter.Match(entry) {
+			logs = append(logs, entry)
+		}
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,86 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_ACTION_LOGS_RESPONSE = `{"message":"ok","terms":"","data":[
+	{"id":1,"productUid":"port-1","actionType":"PROVISIONING","description":"Port deployed","createDate":1704067200000,"personName":"Provisioning","userName":"system"},
+	{"id":2,"productUid":"port-1","actionType":"UPDATE","description":"Name changed","createDate":1704153600000,"personName":"Jo Smith","userName":"jo@example.com","companyName":"Example"},
+	{"id":3,"productUid":"port-1","actionType":"LOCK","description":"Port locked","createDate":1704240000000,"personName":"Jo Smith","userName":"jo@example.com","companyName":"Example"}
+]}`
+
+func TestGetProductActionLogs(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/product/port-1/logs" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found","terms":"","data":""}`))
+			return
+		}
+		w.Write([]byte(TEST_ACTION_LOGS_RESPONSE))
+	})
+	defer server.Close()
+
+	logs, err := p.GetProductActionLogs("port-1", ActionLogFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 3)
+	assert.Equal(t, "UPDATE", logs[1].ActionType)
+	assert.Equal(t, "jo@example.com", logs[1].UserName)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), logs[1].CreateDate.UTC())
+
+	_, err = p.GetProductActionLogs("missing", ActionLogFilter{})
+	assert.True(t, mega_err.IsNotFound(err))
+}
+
+func TestActionLogFilter(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(TEST_ACTION_LOGS_RESPONSE))
+	})
+	defer server.Close()
+
+	tests := map[string]struct {
+		filter   ActionLogFilter
+		expected []int
+	}{
+		"from":        {ActionLogFilter{From: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, []int{2, 3}},
+		"to":          {ActionLogFilter{To: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, []int{1}},
+		"range":       {ActionLogFilter{From: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)}, []int{2}},
+		"action type": {ActionLogFilter{ActionTypes: []string{"lock", "PROVISIONING"}}, []int{1, 3}},
+		"no match":    {ActionLogFilter{ActionTypes: []string{"CANCEL"}}, []int{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			logs, err := p.GetProductActionLogs("port-1", test.filter)
+			assert.NoError(t, err)
+
+			ids := []int{}
+			for _, entry := range logs {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, test.expected, ids)
+		})
+	}
+}
@@ -16,14 +16,16 @@
 package product
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"net/http"
 
 	"github.com/megaport/megaportgo/config"
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 type Product struct {
@@ -36,10 +38,16 @@ func New(cfg *config.Config) *Product {
 	}
 }
 
-// ExecuteOrder executes an order against the Megaport API.
-func (p *Product) ExecuteOrder(requestBody *[]byte) (*[]byte, error) {
+// ExecuteOrder executes an order against the Megaport API, returning the services it created. It returns
+// mega_err.ErrEmptyOrderConfirmation if the API accepts the order without confirming any services.
+func (p *Product) ExecuteOrder(requestBody *[]byte) (types.OrderResult, error) {
+	return p.ExecuteOrderWithContext(context.Background(), requestBody)
+}
+
+// ExecuteOrderWithContext executes an order against the Megaport API.
+func (p *Product) ExecuteOrderWithContext(ctx context.Context, requestBody *[]byte) (types.OrderResult, error) {
 	url := "/v3/networkdesign/buy"
-	response, resErr := p.Config.MakeAPICall("POST", url, *requestBody)
+	response, resErr := p.Config.MakeAPICallWithContext(ctx, "POST", url, *requestBody)
 	// TODO: fix. unit test returns a nil response..
 	if response != nil {
 		p.Log.Debugf("%s %d", url, response.StatusCode)
@@ -52,20 +60,90 @@ func (p *Product) ExecuteOrder(requestBody *[]byte) (*[]byte, error) {
 	isError, parsedError := p.Config.IsErrorResponse(response, &resErr, 200)
 
 	if isError {
-		return nil, parsedError
+		return types.OrderResult{}, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return types.OrderResult{}, fileErr
+	}
+
+	orderInfo := types.OrderResponse{}
+	if unmarshalErr := json.Unmarshal(body, &orderInfo); unmarshalErr != nil {
+		return types.OrderResult{}, unmarshalErr
+	}
+
+	result := types.OrderResult{
+		Message:       orderInfo.Message,
+		Terms:         orderInfo.Terms,
+		Confirmations: orderInfo.Data,
+	}
+
+	if len(result.Confirmations) == 0 || result.TechnicalServiceUID() == "" {
+		return result, mega_err.ErrEmptyOrderConfirmation
+	}
+
+	return result, nil
+}
+
+// ValidateOrder checks an order against the Megaport API without buying it, returning the price of each product. The
+// request body is the same as for ExecuteOrder. If the API rejects the order, the reasons are returned in the quote's
+// Errors rather than as an error; an error is only returned if the order could not be checked.
+func (p *Product) ValidateOrder(requestBody *[]byte) (types.OrderQuote, error) {
+	return p.ValidateOrderWithContext(context.Background(), requestBody)
+}
+
+// ValidateOrderWithContext is the same as ValidateOrder, using the supplied context for the API call.
+func (p *Product) ValidateOrderWithContext(ctx context.Context, requestBody *[]byte) (types.OrderQuote, error) {
+	response, resErr := p.Config.MakeAPICallWithContext(ctx, "POST", "/v3/networkdesign/validate", *requestBody)
+	isError, parsedError := p.Config.IsErrorResponse(response, &resErr, 200)
+
+	if isError {
+		if apiErr, ok := mega_err.AsAPIError(parsedError); ok && apiErr.StatusCode == http.StatusBadRequest {
+			return types.OrderQuote{Valid: false, Errors: validationErrors(apiErr)}, nil
+		}
+		return types.OrderQuote{}, parsedError
 	}
+	defer response.Body.Close()
 
 	body, fileErr := io.ReadAll(response.Body)
 	if fileErr != nil {
-		return nil, fileErr
+		return types.OrderQuote{}, fileErr
+	}
+
+	validation := types.OrderValidateResponse{}
+	if unmarshalErr := json.Unmarshal(body, &validation); unmarshalErr != nil {
+		return types.OrderQuote{}, unmarshalErr
+	}
+
+	return types.OrderQuote{Valid: true, Items: validation.Data}, nil
+}
+
+// validationErrors returns the reasons given by the API for rejecting an order.
+func validationErrors(apiErr *mega_err.APIError) []string {
+	var reasons []string
+
+	if apiErr.Message != "" {
+		reasons = append(reasons, apiErr.Message)
+	}
+
+	if apiErr.Data != "" {
+		reasons = append(reasons, apiErr.Data)
+	} else if apiErr.Body != "" {
+		reasons = append(reasons, apiErr.Body)
 	}
 
-	return &body, nil
+	return reasons
 }
 
 // DeleteProduct is responsible for either scheduling a product for deletion "CANCEL" or deleting a product immediately
 // "CANCEL_NOW".
 func (p *Product) DeleteProduct(id string, deleteNow bool) (bool, error) {
+	return p.DeleteProductWithContext(context.Background(), id, deleteNow)
+}
+
+// DeleteProductWithContext is the same as DeleteProduct, using the supplied context for the API call.
+func (p *Product) DeleteProductWithContext(ctx context.Context, id string, deleteNow bool) (bool, error) {
 	var action string
 
 	if deleteNow {
@@ -75,41 +153,53 @@ func (p *Product) DeleteProduct(id string, deleteNow bool) (bool, error) {
 	}
 
 	url := "/v3/product/" + id + "/action/" + action
-	response, err := p.Config.MakeAPICall("POST", url, nil)
-	defer response.Body.Close()
-
+	response, err := p.Config.MakeAPICallWithContext(ctx, "POST", url, nil)
 	isError, errorMessage := p.Config.IsErrorResponse(response, &err, 200)
 
 	if isError {
 		return false, errorMessage
-	} else {
-		return true, nil
 	}
+	defer response.Body.Close()
+
+	return true, nil
 }
 
 // RestoreProduct will re-enable a Product if a product has been scheduled for deletion.
 func (p *Product) RestoreProduct(id string) (bool, error) {
-	url := "/v2/product/" + id + "/action/UN_CANCEL"
-	response, err := p.Config.MakeAPICall("POST", url, nil)
-	defer response.Body.Close()
+	return p.RestoreProductWithContext(context.Background(), id)
+}
 
+// RestoreProductWithContext is the same as RestoreProduct, using the supplied context for the API call.
+func (p *Product) RestoreProductWithContext(ctx context.Context, id string) (bool, error) {
+	url := "/v2/product/" + id + "/action/UN_CANCEL"
+	response, err := p.Config.MakeAPICallWithContext(ctx, "POST", url, nil)
 	isError, errorMessage := p.Config.IsErrorResponse(response, &err, 200)
 
 	if isError {
 		return false, errorMessage
-	} else {
-		return true, nil
 	}
+	defer response.Body.Close()
+
+	return true, nil
 }
 
 // ModifyProduct modifies a product. The available fields to modify are Name, Cost Centre, and Marketplace Visibility.
 func (p *Product) ModifyProduct(productId string, productType string, name string, costCentre string, marketplaceVisibility bool) (bool, error) {
+	return p.ModifyProductWithContext(context.Background(), productId, productType, name, costCentre, marketplaceVisibility)
+}
+
+// ModifyProductWithContext is the same as ModifyProduct, using the supplied context for the API call.
+func (p *Product) ModifyProductWithContext(ctx context.Context, productId string, productType string, name string, costCentre string, marketplaceVisibility bool) (bool, error) {
 	if productType == types.PRODUCT_MEGAPORT || productType == types.PRODUCT_MCR {
 		update := types.ProductUpdate{
 			Name:                 name,
 			CostCentre:           costCentre,
 			MarketplaceVisbility: marketplaceVisibility,
 		}
+		if err := validation.Validate(update); err != nil {
+			return false, err
+		}
+
 		url := fmt.Sprintf("/v2/product/%s/%s", productType, productId)
 
 		body, marshalErr := json.Marshal(update)
@@ -118,41 +208,54 @@ func (p *Product) ModifyProduct(productId string, productType string, name strin
 			return false, marshalErr
 		}
 
-		updateResponse, err := p.Config.MakeAPICall("PUT", url, []byte(body))
+		updateResponse, err := p.Config.MakeAPICallWithContext(ctx, "PUT", url, []byte(body))
 		isResErr, compiledResErr := p.Config.IsErrorResponse(updateResponse, &err, 200)
 
 		if isResErr {
 			return false, compiledResErr
-		} else {
-			return true, nil
 		}
+		defer updateResponse.Body.Close()
+
+		return true, nil
 	} else {
-		return false, errors.New(mega_err.ERR_WRONG_PRODUCT_MODIFY)
+		return false, mega_err.ErrWrongProductModify
 	}
 
 }
 
 func (p *Product) ManageProductLock(productId string, shouldLock bool) (bool, error) {
+	return p.ManageProductLockWithContext(context.Background(), productId, shouldLock)
+}
+
+// ManageProductLockWithContext is the same as ManageProductLock, using the supplied context for the API call.
+func (p *Product) ManageProductLockWithContext(ctx context.Context, productId string, shouldLock bool) (bool, error) {
 	verb := "POST"
 
 	if !shouldLock {
 		verb = "DELETE"
 	}
 	url := fmt.Sprintf("/v2/product/%s/lock", productId)
-	lockResponse, err := p.Config.MakeAPICall(verb, url, nil)
+	lockResponse, err := p.Config.MakeAPICallWithContext(ctx, verb, url, nil)
 	isResErr, compiledResErr := p.Config.IsErrorResponse(lockResponse, &err, 200)
 	if isResErr {
 		return false, compiledResErr
-	} else {
-		return true, nil
 	}
+	defer lockResponse.Body.Close()
+
+	return true, nil
 }
 
 // GetMCRPrefixFilterLists returns prefix filter lists for the specified MCR2.
 func (p *Product) GetMCRPrefixFilterLists(id string) ([]types.PrefixFilterList, error) {
+	return p.GetMCRPrefixFilterListsWithContext(context.Background(), id)
+}
+
+// GetMCRPrefixFilterListsWithContext is the same as GetMCRPrefixFilterLists, using the supplied context for the API
+// call.
+func (p *Product) GetMCRPrefixFilterListsWithContext(ctx context.Context, id string) ([]types.PrefixFilterList, error) {
 	url := "/v2/product/mcr2/" + id + "/prefixLists?"
 
-	response, err := p.Config.MakeAPICall("GET", url, nil)
+	response, err := p.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
 	isError, errorMessage := p.Config.IsErrorResponse(response, &err, 200)
 
 	if isError {
@@ -177,6 +280,12 @@ func (p *Product) GetMCRPrefixFilterLists(id string) ([]types.PrefixFilterList,
 
 // CreateMCRPrefixFilterList will create an MCR2 product prefix filter list.
 func (p *Product) CreateMCRPrefixFilterList(id string, prefixFilterList types.MCRPrefixFilterList) (bool, error) {
+	return p.CreateMCRPrefixFilterListWithContext(context.Background(), id, prefixFilterList)
+}
+
+// CreateMCRPrefixFilterListWithContext is the same as CreateMCRPrefixFilterList, using the supplied context for the
+// API call.
+func (p *Product) CreateMCRPrefixFilterListWithContext(ctx context.Context, id string, prefixFilterList types.MCRPrefixFilterList) (bool, error) {
 	url := "/v2/product/mcr2/" + id + "/prefixList"
 
 	body, marshalErr := json.Marshal(prefixFilterList)
@@ -184,7 +293,7 @@ func (p *Product) CreateMCRPrefixFilterList(id string, prefixFilterList types.MC
 		return false, marshalErr
 	}
 
-	response, err := p.Config.MakeAPICall("POST", url, []byte(body))
+	response, err := p.Config.MakeAPICallWithContext(ctx, "POST", url, []byte(body))
 	if response != nil {
 		defer response.Body.Close()
 	}
@@ -0,0 +1,114 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_ORDER = `[{"productName":"Port","term":12,"productType":"MEGAPORT","portSpeed":10000,"locationId":1}]`
+
+func TestValidateOrder(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v3/networkdesign/validate", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, TEST_ORDER, string(body))
+
+		w.Write([]byte(`{"message":"Validation passed","terms":"","data":[
+			{"productName":"Port","productType":"MEGAPORT","price":{"currency":"AUD","monthlyRate":1250.5,"monthlySetup":0}},
+			{"productName":"VXC","productType":"VXC","price":{"currency":"AUD","monthlyRate":100}}]}`))
+	})
+	defer server.Close()
+
+	order := []byte(TEST_ORDER)
+	quote, err := p.ValidateOrder(&order)
+	assert.NoError(t, err)
+	assert.True(t, quote.Valid)
+	assert.Empty(t, quote.Errors)
+	assert.Len(t, quote.Items, 2)
+	assert.Equal(t, "AUD", quote.Items[0].Price.Currency)
+	assert.Equal(t, map[string]float64{"AUD": 1350.5}, quote.MonthlyTotals())
+}
+
+func TestValidateOrderRejected(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"Validation failed","terms":"","data":"Port speed 3000 is not available at this location"}`))
+	})
+	defer server.Close()
+
+	order := []byte(TEST_ORDER)
+	quote, err := p.ValidateOrder(&order)
+	assert.NoError(t, err)
+	assert.False(t, quote.Valid)
+	assert.Equal(t, []string{"Validation failed", "Port speed 3000 is not available at this location"}, quote.Errors)
+}
+
+func TestValidateOrderFailure(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Unauthorized","terms":"","data":""}`))
+	})
+	defer server.Close()
+
+	order := []byte(TEST_ORDER)
+	_, err := p.ValidateOrder(&order)
+	assert.ErrorIs(t, err, mega_err.ErrUnauthorized)
+}
+
+func TestExecuteOrder(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v3/networkdesign/buy", r.URL.Path)
+
+		w.Write([]byte(`{"message":"Created","terms":"Terms","data":[
+			{"technicalServiceUid":"port-uid","technicalServiceId":1,"productName":"Port","productType":"MEGAPORT","provisioningStatus":"DEPLOYABLE"},
+			{"vxcJTechnicalServiceUid":"vxc-uid","productName":"VXC","productType":"VXC"}]}`))
+	})
+	defer server.Close()
+
+	order := []byte(TEST_ORDER)
+	result, err := p.ExecuteOrder(&order)
+	assert.NoError(t, err)
+	assert.Equal(t, "Created", result.Message)
+	assert.Equal(t, "port-uid", result.TechnicalServiceUID())
+	assert.Equal(t, []string{"port-uid", "vxc-uid"}, result.UIDs())
+	assert.Equal(t, "DEPLOYABLE", result.Confirmations[0].ProvisioningStatus)
+	assert.Equal(t, "VXC", result.Confirmations[1].ProductType)
+	assert.NotEmpty(t, result.Confirmations[1].Raw)
+}
+
+func TestExecuteOrderEmptyConfirmation(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":"Created","terms":"","data":[]}`))
+	})
+	defer server.Close()
+
+	order := []byte(TEST_ORDER)
+	result, err := p.ExecuteOrder(&order)
+	assert.ErrorIs(t, err, mega_err.ErrEmptyOrderConfirmation)
+	assert.Equal(t, "Created", result.Message)
+	assert.Empty(t, result.TechnicalServiceUID())
+}
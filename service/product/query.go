@@ -0,0 +1,241 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+)
+
+// ProductQuery selects products matching all of its conditions. Build one with Query, e.g.
+//
+//	results, err := p.QueryProducts(product.Query().Type(types.PRODUCT_VXC).Active().NameRegexp("^prod-"))
+//
+// A query is run against the products returned by ListProducts together with their associated VXCs and IXs, so that
+// VXCs and IXs can be queried too.
+type ProductQuery struct {
+	conditions []func(types.Product) bool
+
+	// err is the first error from building the query, returned when it is run.
+	err error
+}
+
+// Query returns a query matching every product.
+func Query() *ProductQuery {
+	return &ProductQuery{}
+}
+
+// Where adds a custom condition.
+func (q *ProductQuery) Where(condition func(types.Product) bool) *ProductQuery {
+	q.conditions = append(q.conditions, condition)
+	return q
+}
+
+// Type matches products of any of the given types, e.g. types.PRODUCT_MEGAPORT. Types are compared ignoring case.
+func (q *ProductQuery) Type(productTypes ...string) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		return containsFold(productTypes, p.GetType())
+	})
+}
+
+// Status matches products with any of the given provisioning statuses, e.g. shared.SERVICE_LIVE.
+func (q *ProductQuery) Status(statuses ...string) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		return containsFold(statuses, p.GetProvisioningStatus())
+	})
+}
+
+// ExcludeStatus matches products with none of the given provisioning statuses.
+func (q *ProductQuery) ExcludeStatus(statuses ...string) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		return !containsFold(statuses, p.GetProvisioningStatus())
+	})
+}
+
+// Live matches products that are configured or live.
+func (q *ProductQuery) Live() *ProductQuery {
+	return q.Status(shared.SERVICE_STATE_READY...)
+}
+
+// Active matches products that have not been decommissioned or cancelled.
+func (q *ProductQuery) Active() *ProductQuery {
+	return q.ExcludeStatus(types.STATUS_DECOMMISSIONED, types.STATUS_CANCELLED)
+}
+
+// Location matches products at any of the given locations. VXCs and IXs are matched by the location of their A-End.
+func (q *ProductQuery) Location(locationIDs ...int) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		for _, id := range locationIDs {
+			if p.GetLocationID() == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// NameRegexp matches products whose name matches the regular expression. An invalid expression is reported when the
+// query is run.
+func (q *ProductQuery) NameRegexp(pattern string) *ProductQuery {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		if q.err == nil {
+			q.err = err
+		}
+		return q
+	}
+
+	return q.Where(func(p types.Product) bool {
+		return re.MatchString(p.GetName())
+	})
+}
+
+// Tag matches products with the attribute tag key set to value.
+func (q *ProductQuery) Tag(key, value string) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		tagValue, ok := p.GetAttributeTags()[key]
+		return ok && tagValue == value
+	})
+}
+
+// HasTag matches products with the attribute tag key set to any value.
+func (q *ProductQuery) HasTag(key string) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		_, ok := p.GetAttributeTags()[key]
+		return ok
+	})
+}
+
+// ContractEndsBefore matches products under a contract ending before t.
+func (q *ProductQuery) ContractEndsBefore(t time.Time) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		end := p.GetContractEndDate()
+		return !end.IsZero() && end.Before(t)
+	})
+}
+
+// ContractEndsAfter matches products under a contract ending at or after t.
+func (q *ProductQuery) ContractEndsAfter(t time.Time) *ProductQuery {
+	return q.Where(func(p types.Product) bool {
+		end := p.GetContractEndDate()
+		return !end.IsZero() && !end.Before(t)
+	})
+}
+
+// Matches reports whether the product satisfies every condition of the query.
+func (q *ProductQuery) Matches(product types.Product) bool {
+	for _, condition := range q.conditions {
+		if !condition(product) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the products, and their associated VXCs and IXs, that match the query.
+func (q *ProductQuery) Filter(products []types.Product) (Products, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	var matched Products
+	for _, product := range FlattenProducts(products) {
+		if q.Matches(product) {
+			matched = append(matched, product)
+		}
+	}
+
+	return matched, nil
+}
+
+// QueryProducts lists the products in the account and returns those matching the query.
+func (p *Product) QueryProducts(query *ProductQuery) (Products, error) {
+	return p.QueryProductsWithContext(context.Background(), query)
+}
+
+// QueryProductsWithContext is the same as QueryProducts, using the supplied context for the API call.
+func (p *Product) QueryProductsWithContext(ctx context.Context, query *ProductQuery) (Products, error) {
+	if query.err != nil {
+		return nil, query.err
+	}
+
+	products, err := p.ListProductsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Filter(products)
+}
+
+// Products is a list of products of any type, with accessors for the products of each type.
+type Products []types.Product
+
+// Ports returns the Ports in the list.
+func (ps Products) Ports() []*types.Port {
+	return productsOfType[*types.Port](ps)
+}
+
+// MCRs returns the MCRs in the list.
+func (ps Products) MCRs() []*types.MCR {
+	return productsOfType[*types.MCR](ps)
+}
+
+// MVEs returns the MVEs in the list.
+func (ps Products) MVEs() []*types.MVE {
+	return productsOfType[*types.MVE](ps)
+}
+
+// VXCs returns the VXCs in the list.
+func (ps Products) VXCs() []*types.VXC {
+	return productsOfType[*types.VXC](ps)
+}
+
+// IXs returns the IXs in the list.
+func (ps Products) IXs() []*types.IX {
+	return productsOfType[*types.IX](ps)
+}
+
+// UIDs returns the UIDs of the products in the list.
+func (ps Products) UIDs() []string {
+	uids := make([]string, 0, len(ps))
+	for _, product := range ps {
+		uids = append(uids, product.GetUID())
+	}
+	return uids
+}
+
+func productsOfType[T types.Product](ps Products) []T {
+	var matched []T
+	for _, product := range ps {
+		if typed, ok := product.(T); ok {
+			matched = append(matched, typed)
+		}
+	}
+	return matched
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProducts() []types.Product {
+	contractEnd := types.NewTimestamp(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	return []types.Product{
+		&types.Port{UID: "port-1", Name: "prod-port", Type: "MEGAPORT", ProvisioningStatus: "LIVE", LocationID: 1,
+			AttributeTags: types.Tags{"env": "prod", "tier": "1"}, ContractEndDate: contractEnd,
+			AssociatedVXCs: []types.VXC{{UID: "vxc-1", Name: "prod-vxc", Type: "VXC", ProvisioningStatus: "LIVE",
+				AEndConfiguration: types.VXCEndConfiguration{LocationID: 1}}}},
+		&types.Port{UID: "port-2", Name: "old-port", Type: "MEGAPORT", ProvisioningStatus: types.STATUS_DECOMMISSIONED, LocationID: 2},
+		&types.MCR{UID: "mcr-1", Name: "prod-mcr", Type: "MCR2", ProvisioningStatus: "CONFIGURED", LocationID: 2,
+			AttributeTags: types.Tags{"env": "prod"}},
+		&types.MVE{UID: "mve-1", Name: "test-mve", Type: "MVE", ProvisioningStatus: types.STATUS_CANCELLED, LocationID: 1},
+	}
+}
+
+func TestQueryFilters(t *testing.T) {
+	products := testProducts()
+
+	tests := map[string]struct {
+		query    *ProductQuery
+		expected []string
+	}{
+		"all":            {Query(), []string{"port-1", "port-2", "mcr-1", "mve-1", "vxc-1"}},
+		"type":           {Query().Type(types.PRODUCT_MEGAPORT), []string{"port-1", "port-2"}},
+		"associated vxc": {Query().Type(types.PRODUCT_VXC), []string{"vxc-1"}},
+		"status":         {Query().Status(shared.SERVICE_LIVE), []string{"port-1", "vxc-1"}},
+		"live":           {Query().Live(), []string{"port-1", "mcr-1", "vxc-1"}},
+		"active":         {Query().Active(), []string{"port-1", "mcr-1", "vxc-1"}},
+		"location":       {Query().Location(2), []string{"port-2", "mcr-1"}},
+		"name":           {Query().NameRegexp("^prod-").Type(types.PRODUCT_MEGAPORT, types.PRODUCT_MCR), []string{"port-1", "mcr-1"}},
+		"tag":            {Query().Tag("env", "prod"), []string{"port-1", "mcr-1"}},
+		"other tag":      {Query().Tag("tier", "1"), []string{"port-1"}},
+		"has tag":        {Query().HasTag("tier"), []string{"port-1"}},
+		"contract":       {Query().ContractEndsBefore(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)), []string{"port-1"}},
+		"contract later": {Query().ContractEndsBefore(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)), nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			results, err := test.query.Filter(products)
+			assert.NoError(t, err)
+			if test.expected == nil {
+				assert.Empty(t, results)
+			} else {
+				assert.Equal(t, test.expected, results.UIDs())
+			}
+		})
+	}
+}
+
+func TestQueryInvalidRegexp(t *testing.T) {
+	_, err := Query().NameRegexp("(").Filter(testProducts())
+	assert.Error(t, err)
+}
+
+func TestQueryProductsTypedResults(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(TEST_PRODUCTS_RESPONSE))
+	})
+	defer server.Close()
+
+	results, err := p.QueryProducts(Query().Live())
+	assert.NoError(t, err)
+
+	assert.Len(t, results.Ports(), 1)
+	assert.Equal(t, 10000, results.Ports()[0].PortSpeed)
+	assert.Len(t, results.MCRs(), 1)
+	assert.Len(t, results.MVEs(), 1)
+	assert.Len(t, results.VXCs(), 1)
+	assert.Len(t, results.IXs(), 1)
+}
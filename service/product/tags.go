@@ -0,0 +1,129 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
+)
+
+// GetProductTags returns the attribute tags of a product of any type.
+func (p *Product) GetProductTags(productUID string) (types.Tags, error) {
+	return p.GetProductTagsWithContext(context.Background(), productUID)
+}
+
+// GetProductTagsWithContext is the same as GetProductTags, using the supplied context for the API call.
+func (p *Product) GetProductTagsWithContext(ctx context.Context, productUID string) (types.Tags, error) {
+	url := fmt.Sprintf("/v2/product/%s/tags", productUID)
+	response, err := p.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
+	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+	defer response.Body.Close()
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	parsed := types.ResourceTagsResponse{}
+	if unmarshalErr := json.Unmarshal(body, &parsed); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return parsed.Tags(), nil
+}
+
+// SetProductTags replaces every attribute tag of a product with tags. Setting no tags removes them all.
+func (p *Product) SetProductTags(productUID string, tags types.Tags) (bool, error) {
+	return p.SetProductTagsWithContext(context.Background(), productUID, tags)
+}
+
+// SetProductTagsWithContext is the same as SetProductTags, using the supplied context for the API call.
+func (p *Product) SetProductTagsWithContext(ctx context.Context, productUID string, tags types.Tags) (bool, error) {
+	if err := validation.ValidateTags(tags); err != nil {
+		return false, err
+	}
+
+	body, marshalErr := json.Marshal(types.ResourceTagsRequest{ResourceTags: tags.ResourceTags()})
+	if marshalErr != nil {
+		return false, marshalErr
+	}
+
+	url := fmt.Sprintf("/v2/product/%s/tags", productUID)
+	response, err := p.Config.MakeAPICallWithContext(ctx, "PUT", url, body)
+	isError, parsedError := p.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return false, parsedError
+	}
+	defer response.Body.Close()
+
+	return true, nil
+}
+
+// MergeProductTags adds tags to those of a product, replacing the values of keys it already has, and returns the
+// product's tags after the change. The tags are read and then written, so concurrent changes to the same product's
+// tags may be lost.
+func (p *Product) MergeProductTags(productUID string, tags types.Tags) (types.Tags, error) {
+	return p.MergeProductTagsWithContext(context.Background(), productUID, tags)
+}
+
+// MergeProductTagsWithContext is the same as MergeProductTags, using the supplied context for the API calls.
+func (p *Product) MergeProductTagsWithContext(ctx context.Context, productUID string, tags types.Tags) (types.Tags, error) {
+	current, err := p.GetProductTagsWithContext(ctx, productUID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := current.Merge(tags)
+	if _, err := p.SetProductTagsWithContext(ctx, productUID, merged); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// DeleteProductTags removes the tags with the given keys from a product and returns the product's remaining tags.
+// Keys the product does not have are ignored. See MergeProductTags regarding concurrent changes.
+func (p *Product) DeleteProductTags(productUID string, keys ...string) (types.Tags, error) {
+	return p.DeleteProductTagsWithContext(context.Background(), productUID, keys...)
+}
+
+// DeleteProductTagsWithContext is the same as DeleteProductTags, using the supplied context for the API calls.
+func (p *Product) DeleteProductTagsWithContext(ctx context.Context, productUID string, keys ...string) (types.Tags, error) {
+	current, err := p.GetProductTagsWithContext(ctx, productUID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := current.Without(keys...)
+	if len(remaining) == len(current) {
+		return remaining, nil
+	}
+
+	if _, err := p.SetProductTagsWithContext(ctx, productUID, remaining); err != nil {
+		return nil, err
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,115 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTagsTestProduct serves the tags of a single product, "port-1", recording each set of tags written.
+func newTagsTestProduct(t *testing.T, tags types.Tags) (*Product, *[]types.Tags, func()) {
+	var writes []types.Tags
+
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/product/port-1/tags", r.URL.Path)
+
+		switch r.Method {
+		case "GET":
+			body, _ := json.Marshal(types.ResourceTagsRequest{ResourceTags: tags.ResourceTags()})
+			w.Write([]byte(`{"message":"ok","terms":"","data":` + string(body) + `}`))
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			request := types.ResourceTagsRequest{}
+			assert.NoError(t, json.Unmarshal(body, &request))
+
+			tags = types.Tags{}
+			for _, tag := range request.ResourceTags {
+				tags[tag.Key] = tag.Value
+			}
+			writes = append(writes, tags)
+			w.Write([]byte(`{"message":"ok","terms":"","data":{}}`))
+		}
+	})
+
+	return p, &writes, server.Close
+}
+
+func TestGetProductTags(t *testing.T) {
+	p, _, closeServer := newTagsTestProduct(t, types.Tags{"owner": "networks", "environment": "production"})
+	defer closeServer()
+
+	tags, err := p.GetProductTags("port-1")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Tags{"owner": "networks", "environment": "production"}, tags)
+}
+
+func TestSetProductTags(t *testing.T) {
+	p, writes, closeServer := newTagsTestProduct(t, types.Tags{"owner": "networks"})
+	defer closeServer()
+
+	set, err := p.SetProductTags("port-1", types.Tags{"environment": "staging"})
+	assert.NoError(t, err)
+	assert.True(t, set)
+	assert.Equal(t, []types.Tags{{"environment": "staging"}}, *writes)
+
+	_, err = p.SetProductTags("port-1", types.Tags{" ": "blank"})
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+	assert.Len(t, *writes, 1)
+}
+
+func TestMergeProductTags(t *testing.T) {
+	p, writes, closeServer := newTagsTestProduct(t, types.Tags{"owner": "networks", "environment": "staging"})
+	defer closeServer()
+
+	tags, err := p.MergeProductTags("port-1", types.Tags{"environment": "production", "tier": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, types.Tags{"owner": "networks", "environment": "production", "tier": "1"}, tags)
+	assert.Equal(t, []types.Tags{tags}, *writes)
+}
+
+func TestDeleteProductTags(t *testing.T) {
+	p, writes, closeServer := newTagsTestProduct(t, types.Tags{"owner": "networks", "environment": "staging"})
+	defer closeServer()
+
+	tags, err := p.DeleteProductTags("port-1", "environment", "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Tags{"owner": "networks"}, tags)
+	assert.Equal(t, []types.Tags{{"owner": "networks"}}, *writes)
+
+	// Deleting keys the product does not have does not write the tags.
+	tags, err = p.DeleteProductTags("port-1", "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Tags{"owner": "networks"}, tags)
+	assert.Len(t, *writes, 1)
+}
+
+func TestDecodeTags(t *testing.T) {
+	port := types.Port{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"attributeTags":{"env":"prod","tier":1,"critical":true}}`), &port))
+	assert.Equal(t, types.Tags{"env": "prod", "tier": "1", "critical": "true"}, port.AttributeTags)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"attributeTags":null}`), &port))
+	assert.Nil(t, port.AttributeTags)
+}
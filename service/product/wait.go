@@ -0,0 +1,131 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+)
+
+// Defaults used by WaitForProduct for unset WaitOptions.
+const (
+	DefaultWaitTimeout  = 5 * time.Minute
+	DefaultWaitInterval = 10 * time.Second
+)
+
+// WaitCondition reports whether a product has reached the state being waited for. Returning an error stops the wait,
+// e.g. when the product can no longer reach that state.
+type WaitCondition func(types.Product) (bool, error)
+
+// WaitOptions configure WaitForProduct.
+type WaitOptions struct {
+	// Timeout is how long to wait before giving up. Defaults to DefaultWaitTimeout.
+	Timeout time.Duration
+
+	// Interval is the time between the first polls. Defaults to DefaultWaitInterval.
+	Interval time.Duration
+
+	// Backoff multiplies the interval after each poll, if greater than 1.
+	Backoff float64
+
+	// MaxInterval caps the interval when Backoff is used. Zero means no limit.
+	MaxInterval time.Duration
+
+	// Progress, if set, is called after each poll that finds the product not yet in the expected state.
+	Progress func(WaitProgress)
+}
+
+// WaitProgress describes a poll made by WaitForProduct.
+type WaitProgress struct {
+	// Product is the product's state when polled.
+	Product types.Product
+
+	// Attempt counts the polls made so far, starting at 1.
+	Attempt int
+
+	// Elapsed is the time since the wait started.
+	Elapsed time.Duration
+
+	// Next is how long until the next poll.
+	Next time.Duration
+}
+
+// ProvisioningReady is a WaitCondition satisfied once a product is configured or live.
+func ProvisioningReady(product types.Product) (bool, error) {
+	return slices.Contains(shared.SERVICE_STATE_READY, product.GetProvisioningStatus()), nil
+}
+
+// WaitForProduct polls the product with the given UID until condition is satisfied, returning the product's final
+// state. It returns mega_err.ErrWaitTimeoutExceed, with the last state polled, if the timeout expires first, and
+// stops early if the context is cancelled or the product cannot be fetched.
+func (p *Product) WaitForProduct(ctx context.Context, productUID string, condition WaitCondition, opts WaitOptions) (types.Product, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		product, err := p.GetProductWithContext(ctx, productUID)
+		if err != nil {
+			return nil, err
+		}
+
+		done, err := condition(product)
+		if err != nil {
+			return product, err
+		} else if done {
+			return product, nil
+		}
+
+		elapsed := time.Since(start)
+		if elapsed >= timeout {
+			return product, mega_err.ErrWaitTimeoutExceed
+		}
+
+		next := interval
+		if remaining := timeout - elapsed; next > remaining {
+			next = remaining
+		}
+
+		p.Log.Debugf("%s %s status is %q - waiting %s", product.GetType(), productUID, product.GetProvisioningStatus(), next)
+
+		if opts.Progress != nil {
+			opts.Progress(WaitProgress{Product: product, Attempt: attempt, Elapsed: elapsed, Next: next})
+		}
+
+		if err := shared.SleepWithContext(ctx, next); err != nil {
+			return product, err
+		}
+
+		if opts.Backoff > 1 {
+			interval = time.Duration(float64(interval) * opts.Backoff)
+			if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+				interval = opts.MaxInterval
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newProvisioningProduct serves a port that becomes LIVE on the given poll, returning the number of polls made.
+func newProvisioningProduct(liveOnPoll int32) (*Product, *int32, func()) {
+	polls := new(int32)
+
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		status := "DEPLOYABLE"
+		if atomic.AddInt32(polls, 1) >= liveOnPoll {
+			status = "LIVE"
+		}
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":"port-1","productType":"MEGAPORT","provisioningStatus":%q}}`, status)
+	})
+
+	return p, polls, server.Close
+}
+
+func TestWaitForProduct(t *testing.T) {
+	p, polls, stop := newProvisioningProduct(3)
+	defer stop()
+
+	var progress []WaitProgress
+	product, err := p.WaitForProduct(context.Background(), "port-1", ProvisioningReady, WaitOptions{
+		Interval: 10 * time.Millisecond,
+		Backoff:  2,
+		Progress: func(wp WaitProgress) { progress = append(progress, wp) },
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "LIVE", product.GetProvisioningStatus())
+	assert.Equal(t, int32(3), atomic.LoadInt32(polls))
+
+	assert.Len(t, progress, 2)
+	assert.Equal(t, 1, progress[0].Attempt)
+	assert.Equal(t, "DEPLOYABLE", progress[0].Product.GetProvisioningStatus())
+	assert.Equal(t, 10*time.Millisecond, progress[0].Next)
+	assert.Equal(t, 20*time.Millisecond, progress[1].Next)
+}
+
+func TestWaitForProductMaxInterval(t *testing.T) {
+	p, _, stop := newProvisioningProduct(4)
+	defer stop()
+
+	var intervals []time.Duration
+	_, err := p.WaitForProduct(context.Background(), "port-1", ProvisioningReady, WaitOptions{
+		Interval:    10 * time.Millisecond,
+		Backoff:     3,
+		MaxInterval: 15 * time.Millisecond,
+		Progress:    func(wp WaitProgress) { intervals = append(intervals, wp.Next) },
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 15 * time.Millisecond, 15 * time.Millisecond}, intervals)
+}
+
+func TestWaitForProductTimeout(t *testing.T) {
+	p, _, stop := newProvisioningProduct(1000)
+	defer stop()
+
+	product, err := p.WaitForProduct(context.Background(), "port-1", ProvisioningReady, WaitOptions{
+		Timeout:  50 * time.Millisecond,
+		Interval: 10 * time.Millisecond,
+	})
+
+	assert.ErrorIs(t, err, mega_err.ErrWaitTimeoutExceed)
+	assert.Equal(t, "DEPLOYABLE", product.GetProvisioningStatus())
+}
+
+func TestWaitForProductCancelled(t *testing.T) {
+	p, _, stop := newProvisioningProduct(1000)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := p.WaitForProduct(ctx, "port-1", ProvisioningReady, WaitOptions{Interval: time.Hour})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForProductConditionError(t *testing.T) {
+	p, polls, stop := newProvisioningProduct(1000)
+	defer stop()
+
+	failed := errors.New("failed")
+	_, err := p.WaitForProduct(context.Background(), "port-1", func(types.Product) (bool, error) {
+		return false, failed
+	}, WaitOptions{Interval: time.Hour})
+
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, int32(1), atomic.LoadInt32(polls))
+}
+
+func TestWaitForProductAPIError(t *testing.T) {
+	server, p := newTestProduct(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Product not found","terms":"","data":""}`))
+	})
+	defer server.Close()
+
+	_, err := p.WaitForProduct(context.Background(), "port-1", ProvisioningReady, WaitOptions{Interval: time.Hour})
+	assert.True(t, mega_err.IsNotFound(err))
+}
@@ -0,0 +1,212 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"time"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// DefaultWatchInterval is the time between polls made by Watch when WatchOptions.Interval is unset.
+const DefaultWatchInterval = 15 * time.Second
+
+// ProductState is the part of a product's state reported by Watch.
+type ProductState struct {
+	ProvisioningStatus string
+	Locked             bool
+	AdminLocked        bool
+
+	// VXCApprovalStatus is the status of any pending approval, for VXCs only.
+	VXCApprovalStatus string
+}
+
+// StateOf returns the watched state of a product.
+func StateOf(product types.Product) ProductState {
+	state := ProductState{
+		ProvisioningStatus: product.GetProvisioningStatus(),
+		Locked:             product.IsLocked(),
+		AdminLocked:        product.IsAdminLocked(),
+	}
+
+	if vxc, ok := product.(*types.VXC); ok {
+		state.VXCApprovalStatus = vxc.VXCApproval.Status
+	}
+
+	return state
+}
+
+// StatusEvent reports a change in the state of a watched product, a failure to fetch it, or an error from
+// WatchOptions.Until.
+type StatusEvent struct {
+	UID  string
+	Time time.Time
+
+	// Product is the product as last polled. It is nil if the product could not be fetched.
+	Product types.Product
+
+	// Previous and Current are the product's state before and after the change. For the first event for a product,
+	// Initial is set and Previous is the zero value.
+	Previous ProductState
+	Current  ProductState
+	Initial  bool
+
+	// Err is set if the product could not be fetched, in which case the watch continues and the product is polled
+	// again, or if WatchOptions.Until returned an error, in which case this is the last event for the product.
+	Err error
+}
+
+// WatchOptions configure Watch.
+type WatchOptions struct {
+	// Interval is the time between polls. Defaults to DefaultWatchInterval.
+	Interval time.Duration
+
+	// Until, if set, stops watching a product once it satisfies the condition, e.g. ProvisioningReady. If the
+	// condition returns an error, a final event for the product is sent with the error in Err and its current state,
+	// then the product is no longer watched. The event channel is closed when no products are left to watch.
+	Until WaitCondition
+}
+
+// Watch polls the products with the given UIDs and sends an event whenever the provisioning status, lock state or
+// VXC approval status of one of them changes, starting with an Initial event for each. When several products are
+// watched they are fetched together using the products listing, falling back to fetching individually any product
+// the listing does not include.
+//
+// The channel is closed when ctx is cancelled, or when every product satisfies WatchOptions.Until or fails it with an
+// error. Callers must keep receiving until then.
+func (p *Product) Watch(ctx context.Context, productUIDs []string, opts WatchOptions) <-chan StatusEvent {
+	events := make(chan StatusEvent)
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	go func() {
+		defer close(events)
+
+		watching := map[string]bool{}
+		for _, uid := range productUIDs {
+			watching[uid] = true
+		}
+		states := map[string]ProductState{}
+
+		send := func(event StatusEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for len(watching) > 0 {
+			products, errs := p.pollProducts(ctx, watching)
+			if ctx.Err() != nil {
+				return
+			}
+			now := time.Now()
+
+			for _, uid := range productUIDs {
+				if !watching[uid] {
+					continue
+				}
+
+				if err, failed := errs[uid]; failed {
+					if !send(StatusEvent{UID: uid, Time: now, Err: err}) {
+						return
+					}
+					continue
+				}
+
+				product := products[uid]
+				current := StateOf(product)
+				previous, seen := states[uid]
+				states[uid] = current
+
+				if !seen || current != previous {
+					event := StatusEvent{UID: uid, Time: now, Product: product, Previous: previous, Current: current, Initial: !seen}
+					if !send(event) {
+						return
+					}
+				}
+
+				if opts.Until != nil {
+					done, err := opts.Until(product)
+					if err != nil {
+						event := StatusEvent{UID: uid, Time: now, Product: product, Previous: current, Current: current, Err: err}
+						if !send(event) {
+							return
+						}
+					}
+					if done || err != nil {
+						delete(watching, uid)
+					}
+				}
+			}
+
+			if len(watching) == 0 {
+				return
+			}
+
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// pollProducts fetches the watched products, using the products listing if more than one is watched. It returns the
+// products found, and an error for each product that could not be fetched.
+func (p *Product) pollProducts(ctx context.Context, watching map[string]bool) (map[string]types.Product, map[string]error) {
+	found := map[string]types.Product{}
+	errs := map[string]error{}
+
+	if len(watching) > 1 {
+		products, err := p.ListProductsWithContext(ctx)
+		if err != nil {
+			for uid := range watching {
+				errs[uid] = err
+			}
+			return found, errs
+		}
+
+		for _, product := range FlattenProducts(products) {
+			if watching[product.GetUID()] {
+				found[product.GetUID()] = product
+			}
+		}
+	}
+
+	for uid := range watching {
+		if _, ok := found[uid]; ok {
+			continue
+		}
+
+		product, err := p.GetProductWithContext(ctx, uid)
+		if err != nil {
+			errs[uid] = err
+			continue
+		}
+		found[uid] = product
+	}
+
+	return found, errs
+}
@@ -0,0 +1,186 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// watchAPI serves a listing containing a port and a VXC whose states advance with each listing, and a standalone
+// product only available individually.
+type watchAPI struct {
+	mu       sync.Mutex
+	listings int
+	gets     []string
+}
+
+func (a *watchAPI) handle(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if r.URL.Path == "/v2/products" {
+		a.listings++
+
+		portStatus, locked, approval := "DEPLOYABLE", false, "PENDING"
+		if a.listings >= 2 {
+			portStatus = "LIVE"
+		}
+		if a.listings >= 3 {
+			locked, approval = true, "APPROVED"
+		}
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"productUid":"port-1","productType":"MEGAPORT",
+			"provisioningStatus":%q,"locked":%t,"associatedVxcs":[{"productUid":"vxc-1","productType":"VXC",
+			"provisioningStatus":"LIVE","vxcApproval":{"status":%q}}]}]}`, portStatus, locked, approval)
+		return
+	}
+
+	uid := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	a.gets = append(a.gets, uid)
+
+	if uid == "missing" {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Product not found","terms":"","data":""}`))
+		return
+	}
+
+	fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"productType":"MVE","provisioningStatus":"LIVE"}}`, uid)
+}
+
+func TestWatch(t *testing.T) {
+	api := &watchAPI{}
+	server, p := newTestProduct(api.handle)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := p.Watch(ctx, []string{"port-1", "vxc-1", "mve-1"}, WatchOptions{Interval: 5 * time.Millisecond})
+
+	var received []string
+	for event := range events {
+		assert.NoError(t, event.Err)
+		received = append(received, fmt.Sprintf("%s %s locked=%t approval=%s initial=%t", event.UID,
+			event.Current.ProvisioningStatus, event.Current.Locked, event.Current.VXCApprovalStatus, event.Initial))
+
+		if len(received) == 6 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []string{
+		"port-1 DEPLOYABLE locked=false approval= initial=true",
+		"vxc-1 LIVE locked=false approval=PENDING initial=true",
+		"mve-1 LIVE locked=false approval= initial=true",
+		"port-1 LIVE locked=false approval= initial=false",
+		"port-1 LIVE locked=true approval= initial=false",
+		"vxc-1 LIVE locked=false approval=APPROVED initial=false",
+	}, received)
+
+	// Only the product missing from the listing is fetched individually.
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	for _, uid := range api.gets {
+		assert.Equal(t, "mve-1", uid)
+	}
+}
+
+func TestWatchUntil(t *testing.T) {
+	api := &watchAPI{}
+	server, p := newTestProduct(api.handle)
+	defer server.Close()
+
+	events := p.Watch(context.Background(), []string{"port-1"}, WatchOptions{
+		Interval: 5 * time.Millisecond,
+		Until:    ProvisioningReady,
+	})
+
+	var statuses []string
+	for event := range events {
+		statuses = append(statuses, event.Current.ProvisioningStatus)
+	}
+
+	// A single product is fetched on its own rather than from the listing. It is already live, so the watch ends
+	// after the first event.
+	assert.Equal(t, []string{"LIVE"}, statuses)
+	assert.Equal(t, 0, api.listings)
+}
+
+func TestWatchUntilError(t *testing.T) {
+	api := &watchAPI{}
+	server, p := newTestProduct(api.handle)
+	defer server.Close()
+
+	errUnreachable := errors.New("the product can no longer reach the state")
+	events := p.Watch(context.Background(), []string{"mve-1"}, WatchOptions{
+		Interval: 5 * time.Millisecond,
+		Until: func(product types.Product) (bool, error) {
+			return false, errUnreachable
+		},
+	})
+
+	var received []StatusEvent
+	for event := range events {
+		received = append(received, event)
+	}
+
+	// The initial event is followed by a final event with the error, then the watch ends.
+	if assert.Len(t, received, 2) {
+		assert.True(t, received[0].Initial)
+		assert.NoError(t, received[0].Err)
+
+		assert.Equal(t, "mve-1", received[1].UID)
+		assert.ErrorIs(t, received[1].Err, errUnreachable)
+		assert.NotNil(t, received[1].Product)
+		assert.Equal(t, "LIVE", received[1].Current.ProvisioningStatus)
+	}
+}
+
+func TestWatchErrors(t *testing.T) {
+	api := &watchAPI{}
+	server, p := newTestProduct(api.handle)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := p.Watch(ctx, []string{"missing", "port-1"}, WatchOptions{Interval: 5 * time.Millisecond})
+
+	event := <-events
+	assert.Equal(t, "missing", event.UID)
+	assert.Error(t, event.Err)
+	assert.Nil(t, event.Product)
+
+	event = <-events
+	assert.Equal(t, "port-1", event.UID)
+	assert.NoError(t, event.Err)
+
+	cancel()
+	for range events {
+	}
+}
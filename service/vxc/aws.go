@@ -17,9 +17,11 @@
 package vxc
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 // BuyAWSVXC buys an AWS VXC.
@@ -29,38 +31,80 @@ func (v *VXC) BuyAWSVXC(
 	rateLimit int,
 	aEndConfiguration types.VXCOrderAEndConfiguration,
 	bEndConfiguration types.AWSVXCOrderBEndConfiguration,
-) (string, error) {
-
-	buyOrder := []types.AWSVXCOrder{
-		{
-			PortID: portUID,
-			AssociatedVXCs: []types.AWSVXCOrderConfiguration{
-				{
-					Name:      vxcName,
-					RateLimit: rateLimit,
-					AEnd:      aEndConfiguration,
-					BEnd:      bEndConfiguration,
-				},
-			},
-		},
+) (types.OrderResult, error) {
+	return v.BuyAWSVXCWithContext(context.Background(), portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+}
+
+// BuyAWSVXCWithContext buys an AWS VXC, using the supplied context for the API call.
+func (v *VXC) BuyAWSVXCWithContext(
+	ctx context.Context,
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.AWSVXCOrderBEndConfiguration,
+) (types.OrderResult, error) {
+	requestBody, err := buildAWSVXCOrder(portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+	if err != nil {
+		return types.OrderResult{}, err
 	}
 
-	requestBody, _ := json.Marshal(buyOrder)
+	return v.product.ExecuteOrderWithContext(ctx, &requestBody)
+}
 
-	responseBody, responseErr := v.product.ExecuteOrder(&requestBody)
+// ValidateAWSVXCOrder checks the order BuyAWSVXC would place, without buying the VXC, and returns its price.
+func (v *VXC) ValidateAWSVXCOrder(
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.AWSVXCOrderBEndConfiguration,
+) (types.OrderQuote, error) {
+	return v.ValidateAWSVXCOrderWithContext(context.Background(), portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+}
 
-	if responseErr != nil {
-		return "", responseErr
+// ValidateAWSVXCOrderWithContext is the same as ValidateAWSVXCOrder, using the supplied context for the API call.
+func (v *VXC) ValidateAWSVXCOrderWithContext(
+	ctx context.Context,
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.AWSVXCOrderBEndConfiguration,
+) (types.OrderQuote, error) {
+	requestBody, err := buildAWSVXCOrder(portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+	if err != nil {
+		return types.OrderQuote{}, err
 	}
 
-	orderInfo := types.VXCOrderResponse{}
-	err := json.Unmarshal(*responseBody, &orderInfo)
+	return v.product.ValidateOrderWithContext(ctx, &requestBody)
+}
 
-	if err != nil {
-		return "", err
+// buildAWSVXCOrder returns the order for an AWS VXC, as sent to the API by BuyAWSVXC and ValidateAWSVXCOrder.
+func buildAWSVXCOrder(
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.AWSVXCOrderBEndConfiguration,
+) ([]byte, error) {
+	order := types.AWSVXCOrder{
+		PortID: portUID,
+		AssociatedVXCs: []types.AWSVXCOrderConfiguration{
+			{
+				Name:      vxcName,
+				RateLimit: rateLimit,
+				AEnd:      aEndConfiguration,
+				BEnd:      bEndConfiguration,
+			},
+		},
+	}
+
+	if err := validation.Validate(order); err != nil {
+		return nil, err
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	return json.Marshal([]types.AWSVXCOrder{order})
 }
 
 func (v *VXC) ExtractAwsId(vxcDetails types.VXC) string {
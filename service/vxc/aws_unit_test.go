@@ -154,13 +154,14 @@ func Test_VXC_MCR_AWS(t *testing.T) {
 		},
 	}
 
-	vxcId, vxcError := vxcService.BuyAWSVXC(
+	vxcOrder, vxcError := vxcService.BuyAWSVXC(
 		"mcr-id-here",
 		vxcName,
 		vxcRteLimit,
 		aEndConfiguration,
 		bEndConfiguration,
 	)
+	vxcId := vxcOrder.TechnicalServiceUID()
 
 	assert.True(t, vxcId != "", vxcError)
 
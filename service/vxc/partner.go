@@ -15,13 +15,14 @@
 package vxc
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"strings"
 
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 const PARTNER_AZURE string = "AZURE"
@@ -32,14 +33,19 @@ const PARTNER_OCI string = "ORACLE"
 // LookupPartnerPorts is used to find available partner ports. This is Step 1 of the purchase process for most partner
 // ports as outlined at https://dev.megaport.com/#cloud-partner-api-orders.
 func (v *VXC) LookupPartnerPorts(key string, portSpeed int, partner string, requestedProductID string) (string, error) {
+	return v.LookupPartnerPortsWithContext(context.Background(), key, portSpeed, partner, requestedProductID)
+}
+
+// LookupPartnerPortsWithContext is the same as LookupPartnerPorts, using the supplied context for the API call.
+func (v *VXC) LookupPartnerPortsWithContext(ctx context.Context, key string, portSpeed int, partner string, requestedProductID string) (string, error) {
 	lookupUrl := "/v2/secure/" + strings.ToLower(partner) + "/" + key
-	response, resErr := v.Config.MakeAPICall("GET", lookupUrl, nil)
-	defer response.Body.Close()
+	response, resErr := v.Config.MakeAPICallWithContext(ctx, "GET", lookupUrl, nil)
 	isErr, compiledErr := v.Config.IsErrorResponse(response, &resErr, 200)
 
 	if isErr {
 		return "", compiledErr
 	}
+	defer response.Body.Close()
 
 	body, fileErr := io.ReadAll(response.Body)
 
@@ -66,7 +72,7 @@ func (v *VXC) LookupPartnerPorts(key string, portSpeed int, partner string, requ
 		}
 	}
 
-	return "", errors.New(mega_err.ERR_NO_AVAILABLE_VXC_PORTS)
+	return "", mega_err.ErrNoAvailableVXCPorts
 }
 
 // BuyAWSVXC buys an AWS VXC.
@@ -76,38 +82,80 @@ func (v *VXC) BuyPartnerVXC(
 	rateLimit int,
 	aEndConfiguration types.VXCOrderAEndConfiguration,
 	bEndConfiguration types.PartnerOrderBEndConfiguration,
-) (string, error) {
-
-	buyOrder := []types.PartnerOrder{
-		{
-			PortID: portUID,
-			AssociatedVXCs: []types.PartnerOrderContents{
-				{
-					Name:      vxcName,
-					RateLimit: rateLimit,
-					AEnd:      aEndConfiguration,
-					BEnd:      bEndConfiguration,
-				},
-			},
-		},
+) (types.OrderResult, error) {
+	return v.BuyPartnerVXCWithContext(context.Background(), portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+}
+
+// BuyPartnerVXCWithContext buys a partner VXC, using the supplied context for the API call.
+func (v *VXC) BuyPartnerVXCWithContext(
+	ctx context.Context,
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.PartnerOrderBEndConfiguration,
+) (types.OrderResult, error) {
+	requestBody, err := buildPartnerVXCOrder(portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+	if err != nil {
+		return types.OrderResult{}, err
 	}
 
-	requestBody, _ := json.Marshal(buyOrder)
+	return v.product.ExecuteOrderWithContext(ctx, &requestBody)
+}
 
-	responseBody, responseErr := v.product.ExecuteOrder(&requestBody)
+// ValidatePartnerVXCOrder checks the order BuyPartnerVXC would place, without buying the VXC, and returns its price.
+func (v *VXC) ValidatePartnerVXCOrder(
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.PartnerOrderBEndConfiguration,
+) (types.OrderQuote, error) {
+	return v.ValidatePartnerVXCOrderWithContext(context.Background(), portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+}
 
-	if responseErr != nil {
-		return "", responseErr
+// ValidatePartnerVXCOrderWithContext is the same as ValidatePartnerVXCOrder, using the supplied context for the API call.
+func (v *VXC) ValidatePartnerVXCOrderWithContext(
+	ctx context.Context,
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.PartnerOrderBEndConfiguration,
+) (types.OrderQuote, error) {
+	requestBody, err := buildPartnerVXCOrder(portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+	if err != nil {
+		return types.OrderQuote{}, err
 	}
 
-	orderInfo := types.VXCOrderResponse{}
-	err := json.Unmarshal(*responseBody, &orderInfo)
+	return v.product.ValidateOrderWithContext(ctx, &requestBody)
+}
 
-	if err != nil {
-		return "", err
+// buildPartnerVXCOrder returns the order for a partner VXC, as sent to the API by BuyPartnerVXC and ValidatePartnerVXCOrder.
+func buildPartnerVXCOrder(
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.PartnerOrderBEndConfiguration,
+) ([]byte, error) {
+	order := types.PartnerOrder{
+		PortID: portUID,
+		AssociatedVXCs: []types.PartnerOrderContents{
+			{
+				Name:      vxcName,
+				RateLimit: rateLimit,
+				AEnd:      aEndConfiguration,
+				BEnd:      bEndConfiguration,
+			},
+		},
+	}
+
+	if err := validation.Validate(order); err != nil {
+		return nil, err
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	return json.Marshal([]types.PartnerOrder{order})
 }
 
 // BuyPartnerVXC performs Step 2 of the partner port purchase process. These are for partners that require some kind
@@ -149,7 +197,7 @@ func (v *VXC) MarshallPartnerConfig(
 			VirtualCircutId: key,
 		}
 	} else {
-		return "", errors.New(mega_err.ERR_INVALID_PARTNER)
+		return "", mega_err.ErrInvalidPartner
 	}
 
 	return partnerConfig, nil
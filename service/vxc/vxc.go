@@ -15,18 +15,18 @@
 package vxc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"slices"
 	"time"
 
 	"github.com/megaport/megaportgo/config"
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/service/product"
-	"github.com/megaport/megaportgo/shared"
 	"github.com/megaport/megaportgo/types"
+	"github.com/megaport/megaportgo/validation"
 )
 
 type VXC struct {
@@ -47,9 +47,64 @@ func (v *VXC) BuyVXC(
 	rateLimit int,
 	aEndConfiguration types.VXCOrderAEndConfiguration,
 	bEndConfiguration types.VXCOrderBEndConfiguration,
-) (string, error) {
+) (types.OrderResult, error) {
+	return v.BuyVXCWithContext(context.Background(), portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+}
+
+// BuyVXCWithContext is the same as BuyVXC, using the supplied context for the API call.
+func (v *VXC) BuyVXCWithContext(
+	ctx context.Context,
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.VXCOrderBEndConfiguration,
+) (types.OrderResult, error) {
+	requestBody, err := buildVXCOrder(portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+	if err != nil {
+		return types.OrderResult{}, err
+	}
 
-	buyOrder := []types.VXCOrder{{
+	return v.product.ExecuteOrderWithContext(ctx, &requestBody)
+}
+
+// ValidateVXCOrder checks the order BuyVXC would place, without buying the VXC, and returns its price.
+func (v *VXC) ValidateVXCOrder(
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.VXCOrderBEndConfiguration,
+) (types.OrderQuote, error) {
+	return v.ValidateVXCOrderWithContext(context.Background(), portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+}
+
+// ValidateVXCOrderWithContext is the same as ValidateVXCOrder, using the supplied context for the API call.
+func (v *VXC) ValidateVXCOrderWithContext(
+	ctx context.Context,
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.VXCOrderBEndConfiguration,
+) (types.OrderQuote, error) {
+	requestBody, err := buildVXCOrder(portUID, vxcName, rateLimit, aEndConfiguration, bEndConfiguration)
+	if err != nil {
+		return types.OrderQuote{}, err
+	}
+
+	return v.product.ValidateOrderWithContext(ctx, &requestBody)
+}
+
+// buildVXCOrder returns the order for a VXC, as sent to the API by BuyVXC and ValidateVXCOrder.
+func buildVXCOrder(
+	portUID string,
+	vxcName string,
+	rateLimit int,
+	aEndConfiguration types.VXCOrderAEndConfiguration,
+	bEndConfiguration types.VXCOrderBEndConfiguration,
+) ([]byte, error) {
+	order := types.VXCOrder{
 		PortID: portUID,
 		AssociatedVXCs: []types.VXCOrderConfiguration{
 			{
@@ -59,33 +114,30 @@ func (v *VXC) BuyVXC(
 				BEnd:      bEndConfiguration,
 			},
 		},
-	}}
-
-	requestBody, _ := json.Marshal(buyOrder)
-	responseBody, responseErr := v.product.ExecuteOrder(&requestBody)
-	if responseErr != nil {
-		return "", responseErr
 	}
 
-	orderInfo := types.VXCOrderResponse{}
-	err := json.Unmarshal(*responseBody, &orderInfo)
-
-	if err != nil {
-		return "", err
+	if err := validation.Validate(order); err != nil {
+		return nil, err
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	return json.Marshal([]types.VXCOrder{order})
 }
 
 // GetVXCDetails gets the details of a VXC.
 func (v *VXC) GetVXCDetails(id string) (types.VXC, error) {
+	return v.GetVXCDetailsWithContext(context.Background(), id)
+}
+
+// GetVXCDetailsWithContext gets the details of a VXC, using the supplied context for the API call.
+func (v *VXC) GetVXCDetailsWithContext(ctx context.Context, id string) (types.VXC, error) {
 	url := "/v2/product/" + id
-	response, err := v.Config.MakeAPICall("GET", url, nil)
-	defer response.Body.Close()
+	response, err := v.Config.MakeAPICallWithContext(ctx, "GET", url, nil)
+	isError, parsedError := v.Config.IsErrorResponse(response, &err, 200)
 
-	if err != nil {
-		return types.VXC{}, err
+	if isError {
+		return types.VXC{}, parsedError
 	}
+	defer response.Body.Close()
 
 	body, fileErr := ioutil.ReadAll(response.Body)
 
@@ -108,7 +160,37 @@ func (v *VXC) DeleteVXC(id string, deleteNow bool) (bool, error) {
 	return v.product.DeleteProduct(id, deleteNow)
 }
 
+// DeleteVXCWithContext deletes a VXC, using the supplied context for the API call.
+func (v *VXC) DeleteVXCWithContext(ctx context.Context, id string, deleteNow bool) (bool, error) {
+	return v.product.DeleteProductWithContext(ctx, id, deleteNow)
+}
+
+// LockVXC locks a VXC against changes. See Product.LockProduct for the errors returned.
+func (v *VXC) LockVXC(id string) (bool, error) {
+	return v.LockVXCWithContext(context.Background(), id)
+}
+
+// LockVXCWithContext is the same as LockVXC, using the supplied context for the API calls.
+func (v *VXC) LockVXCWithContext(ctx context.Context, id string) (bool, error) {
+	return v.product.LockProductWithContext(ctx, id)
+}
+
+// UnlockVXC unlocks a locked VXC. See Product.UnlockProduct for the errors returned.
+func (v *VXC) UnlockVXC(id string) (bool, error) {
+	return v.UnlockVXCWithContext(context.Background(), id)
+}
+
+// UnlockVXCWithContext is the same as UnlockVXC, using the supplied context for the API calls.
+func (v *VXC) UnlockVXCWithContext(ctx context.Context, id string) (bool, error) {
+	return v.product.UnlockProductWithContext(ctx, id)
+}
+
 func (v *VXC) UpdateVXC(id string, name string, rateLimit int, aEndVLAN int, bEndVLAN int) (bool, error) {
+	return v.UpdateVXCWithContext(context.Background(), id, name, rateLimit, aEndVLAN, bEndVLAN)
+}
+
+// UpdateVXCWithContext is the same as UpdateVXC, using the supplied context for the API call.
+func (v *VXC) UpdateVXCWithContext(ctx context.Context, id string, name string, rateLimit int, aEndVLAN int, bEndVLAN int) (bool, error) {
 	url := fmt.Sprintf("/v2/product/%s/%s", types.PRODUCT_VXC, id)
 	var update interface{}
 
@@ -127,84 +209,85 @@ func (v *VXC) UpdateVXC(id string, name string, rateLimit int, aEndVLAN int, bEn
 		}
 	}
 
+	if err := validation.Validate(update); err != nil {
+		return false, err
+	}
+
 	body, marshalErr := json.Marshal(update)
 
 	if marshalErr != nil {
 		return false, marshalErr
 	}
 
-	updateResponse, err := v.Config.MakeAPICall("PUT", url, []byte(body))
+	updateResponse, err := v.Config.MakeAPICallWithContext(ctx, "PUT", url, []byte(body))
 	isResErr, compiledResErr := v.Config.IsErrorResponse(updateResponse, &err, 200)
 
 	if isResErr {
 		return false, compiledResErr
-	} else {
-		return true, nil
 	}
+	defer updateResponse.Body.Close()
+
+	return true, nil
 }
 
 func (v *VXC) WaitForVXCProvisioning(vxcId string) (bool, error) {
-	// Try for ~5mins.
-	for i := 0; i < 30; i++ {
-		details, err := v.GetVXCDetails(vxcId)
-		if err != nil {
-			return false, err
-		}
+	return v.WaitForVXCProvisioningWithContext(context.Background(), vxcId)
+}
 
-		if slices.Contains(shared.SERVICE_STATE_READY, details.ProvisioningStatus) {
-			return true, nil
-		}
+// WaitForVXCProvisioningWithContext is the same as WaitForVXCProvisioning, but stops waiting when the context is
+// cancelled.
+func (v *VXC) WaitForVXCProvisioningWithContext(ctx context.Context, vxcId string) (bool, error) {
+	return v.WaitForVXCProvisioningWithOptions(ctx, vxcId, product.WaitOptions{})
+}
 
-		// Wrong status, wait a bit and try again.
-		v.Log.Debugf("VXC status is %q - waiting", details.ProvisioningStatus)
-		time.Sleep(10 * time.Second)
+// WaitForVXCProvisioningWithOptions is the same as WaitForVXCProvisioningWithContext, polling according to opts instead
+// of for up to five minutes every ten seconds.
+func (v *VXC) WaitForVXCProvisioningWithOptions(ctx context.Context, vxcId string, opts product.WaitOptions) (bool, error) {
+	_, err := v.product.WaitForProduct(ctx, vxcId, product.ProvisioningReady, opts)
+	if errors.Is(err, mega_err.ErrWaitTimeoutExceed) {
+		return false, mega_err.ErrVXCProvisionTimeoutExceed
+	} else if err != nil {
+		return false, err
 	}
 
-	return false, errors.New(mega_err.ERR_VXC_PROVISION_TIMEOUT_EXCEED)
+	return true, nil
 }
 
 func (v *VXC) WaitForVXCUpdated(id string, name string, rateLimit int, aEndVLAN int, bEndVLAN int) (bool, error) {
-	wait := 0
-	hasUpdated := false
-
-	for !hasUpdated && wait < 30 {
-		time.Sleep(30 * time.Second)
-		wait++
-		vxcDetails, _ := v.GetVXCDetails(id)
-
-		if aEndVLAN == 0 {
-			aEndVLAN = vxcDetails.AEndConfiguration.VLAN
-		}
+	return v.WaitForVXCUpdatedWithContext(context.Background(), id, name, rateLimit, aEndVLAN, bEndVLAN)
+}
 
-		if bEndVLAN == 0 {
-			bEndVLAN = vxcDetails.BEndConfiguration.VLAN
-		}
+// WaitForVXCUpdatedWithContext is the same as WaitForVXCUpdated, but stops waiting when the context is cancelled.
+func (v *VXC) WaitForVXCUpdatedWithContext(ctx context.Context, id string, name string, rateLimit int, aEndVLAN int, bEndVLAN int) (bool, error) {
+	return v.WaitForVXCUpdatedWithOptions(ctx, id, name, rateLimit, aEndVLAN, bEndVLAN, product.WaitOptions{
+		Timeout:  15 * time.Minute,
+		Interval: 30 * time.Second,
+	})
+}
 
-		if wait%5 == 0 {
-			v.Log.Debugf("VXC Update in progress: Name %t; RateLimit %t; AEndVLAN %t; BEndVLAN %t\n",
-				vxcDetails.Name == name,
-				vxcDetails.RateLimit == rateLimit,
-				vxcDetails.AEndConfiguration.VLAN == aEndVLAN,
-				vxcDetails.BEndConfiguration.VLAN == bEndVLAN)
+// WaitForVXCUpdatedWithOptions is the same as WaitForVXCUpdatedWithContext, polling according to opts instead of for up
+// to fifteen minutes every thirty seconds. A VLAN of 0 matches any VLAN.
+func (v *VXC) WaitForVXCUpdatedWithOptions(ctx context.Context, id string, name string, rateLimit int, aEndVLAN int, bEndVLAN int, opts product.WaitOptions) (bool, error) {
+	updated := func(p types.Product) (bool, error) {
+		vxcDetails, ok := p.(*types.VXC)
+		if !ok {
+			return false, fmt.Errorf("product %s is a %s, not a VXC", id, p.GetType())
 		}
 
-		if vxcDetails.Name == name && vxcDetails.RateLimit == rateLimit && vxcDetails.AEndConfiguration.VLAN == aEndVLAN && vxcDetails.BEndConfiguration.VLAN == bEndVLAN {
-			hasUpdated = true
-		}
+		return vxcDetails.Name == name &&
+			vxcDetails.RateLimit == rateLimit &&
+			(aEndVLAN == 0 || vxcDetails.AEndConfiguration.VLAN == aEndVLAN) &&
+			(bEndVLAN == 0 || vxcDetails.BEndConfiguration.VLAN == bEndVLAN), nil
 	}
 
-	vxcDetails, _ := v.GetVXCDetails(id)
-	v.Log.Debugf("VXC wait cyclecomplete: Name %t; RateLimit %t; AEndVLAN %t; BEndVLAN %t\n",
-		vxcDetails.Name == name,
-		vxcDetails.RateLimit == rateLimit,
-		vxcDetails.AEndConfiguration.VLAN == aEndVLAN,
-		vxcDetails.BEndConfiguration.VLAN == bEndVLAN)
-
-	if wait >= 30 {
-		return false, errors.New(mega_err.ERR_VXC_UPDATE_TIMEOUT_EXCEED)
-	} else {
-		return true, nil
+	_, err := v.product.WaitForProduct(ctx, id, updated, opts)
+	if errors.Is(err, mega_err.ErrWaitTimeoutExceed) {
+		return false, mega_err.ErrVXCUpdateTimeoutExceed
+	} else if err != nil {
+		return false, err
 	}
+
+	return true, nil
 }
 
 func (v *VXC) UnmarshallMcrAEndConfig(vxcDetails types.VXC) (interface{}, error) {
@@ -391,3 +474,9 @@ func (v *VXC) GetPrefixFilterLists(id string) ([]types.PrefixFilterList, error)
 	prefix, prefixErr := v.product.GetMCRPrefixFilterLists(id)
 	return prefix, prefixErr
 }
+
+// GetPrefixFilterListsWithContext returns all Prefix Filter Lists on an MCR, using the supplied context for the API
+// call.
+func (v *VXC) GetPrefixFilterListsWithContext(ctx context.Context, id string) ([]types.PrefixFilterList, error) {
+	return v.product.GetMCRPrefixFilterListsWithContext(ctx, id)
+}
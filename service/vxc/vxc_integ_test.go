@@ -94,7 +94,8 @@ func TestVXCBuy(t *testing.T) {
 	assert.NoError(locationErr)
 
 	logger.Info("Buying Port (A End).")
-	aEnd, aErr := port.BuySinglePort("VXC Port A", 1, 1000, testLocation.ID, "AU", true)
+	aEndOrder, aErr := port.BuySinglePort("VXC Port A", 1, 1000, testLocation.ID, "AU", true)
+	aEnd := aEndOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", aEnd)
 
 	if !assert.NoError(aErr) && !assert.True(shared.IsGuid(aEnd)) {
@@ -103,7 +104,8 @@ func TestVXCBuy(t *testing.T) {
 	}
 
 	logger.Info("Buying Port (B End).")
-	bEnd, bErr := port.BuySinglePort("VXC Port B", 1, 1000, testLocation.ID, "AU", true)
+	bEndOrder, bErr := port.BuySinglePort("VXC Port B", 1, 1000, testLocation.ID, "AU", true)
+	bEnd := bEndOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", bEnd)
 
 	if !assert.NoError(bErr) && !assert.True(shared.IsGuid(bEnd)) {
@@ -117,7 +119,7 @@ func TestVXCBuy(t *testing.T) {
 	logger.Info("Buying VXC.")
 
 	logger.Info("Buying AWS Hosted Connection (B End).")
-	vxcId, vxcErr := vxc.BuyVXC(
+	vxcOrder, vxcErr := vxc.BuyVXC(
 		aEnd,
 		"Test VXC",
 		500,
@@ -129,6 +131,7 @@ func TestVXCBuy(t *testing.T) {
 			ProductUID: bEnd,
 		},
 	)
+	vxcId := vxcOrder.TechnicalServiceUID()
 
 	logger.Infof("VXC Purchased: %s", vxcId)
 
@@ -172,7 +175,8 @@ func TestAWSVIFConnectionBuy(t *testing.T) {
 	testLocation, _ := loc.GetLocationByName(TEST_LOCATION_B)
 
 	logger.Info("Buying AWS VIF Port (A End).")
-	portId, portErr := port.BuySinglePort("AWS VIF Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portOrder, portErr := port.BuySinglePort("AWS VIF Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portId := portOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", portId)
 
 	if !assert.NoError(t, portErr) && !assert.True(t, shared.IsGuid(portId)) {
@@ -183,7 +187,7 @@ func TestAWSVIFConnectionBuy(t *testing.T) {
 	port.WaitForPortProvisioning(portId)
 
 	logger.Info("Buying AWS VIF Connection (B End).")
-	hostedVifId, hostedVifErr := vxc.BuyAWSVXC(
+	hostedVifOrder, hostedVifErr := vxc.BuyAWSVXC(
 		portId,
 		"Hosted AWS VIF Test Connection",
 		500,
@@ -203,6 +207,7 @@ func TestAWSVIFConnectionBuy(t *testing.T) {
 			},
 		},
 	)
+	hostedVifId := hostedVifOrder.TechnicalServiceUID()
 
 	logger.Infof("AWS VIF Connection ID: %s", hostedVifId)
 
@@ -230,7 +235,8 @@ func TestAWSHostedConnectionBuy(t *testing.T) {
 	testLocation := loc.GetRandom(MCR_LOCATION)
 
 	logger.Info("Buying AWS Hosted Connection MCR (A End).")
-	mcrId, mcrErr := mcr.BuyMCR(testLocation.ID, "AWS Hosted Conection Test MCR", 1, 1000, 0)
+	mcrOrder, mcrErr := mcr.BuyMCR(testLocation.ID, "AWS Hosted Conection Test MCR", 1, 1000, 0)
+	mcrId := mcrOrder.TechnicalServiceUID()
 	logger.Infof("MCR Purchased: %s", mcrId)
 
 	if !assert.NoError(t, mcrErr) && !assert.True(t, shared.IsGuid(mcrId)) {
@@ -241,7 +247,7 @@ func TestAWSHostedConnectionBuy(t *testing.T) {
 	mcr.WaitForMcrProvisioning(mcrId)
 
 	logger.Info("Buying AWS Hosted Connection (B End).")
-	hostedConnectionId, hostedConnectionErr := vxc.BuyAWSVXC(
+	hostedConnectionOrder, hostedConnectionErr := vxc.BuyAWSVXC(
 		mcrId,
 		"Hosted Connection AWS Test Connection",
 		500,
@@ -290,6 +296,7 @@ func TestAWSHostedConnectionBuy(t *testing.T) {
 			},
 		},
 	)
+	hostedConnectionId := hostedConnectionOrder.TechnicalServiceUID()
 	logger.Infof("AWS Hosted Connection ID: %s", hostedConnectionId)
 
 	if !assert.NoError(t, hostedConnectionErr) && !assert.True(t, shared.IsGuid(hostedConnectionId)) {
@@ -316,7 +323,8 @@ func TestAWSConnectionBuyDefaults(t *testing.T) {
 	testLocation, _ := loc.GetLocationByName(TEST_LOCATION_B)
 
 	logger.Info("Buying AWS VIF Port (A End).")
-	portId, portErr := port.BuySinglePort("AWS VIF Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portOrder, portErr := port.BuySinglePort("AWS VIF Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portId := portOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", portId)
 
 	if !assert.NoError(t, portErr) && !assert.True(t, shared.IsGuid(portId)) {
@@ -326,7 +334,7 @@ func TestAWSConnectionBuyDefaults(t *testing.T) {
 	port.WaitForPortProvisioning(portId)
 
 	logger.Info("Buying AWS VIF Connection (B End).")
-	hostedVifId, hostedVifErr := vxc.BuyAWSVXC(
+	hostedVifOrder, hostedVifErr := vxc.BuyAWSVXC(
 		portId,
 		"Hosted AWS VIF Test Connection",
 		500,
@@ -344,6 +352,7 @@ func TestAWSConnectionBuyDefaults(t *testing.T) {
 			},
 		},
 	)
+	hostedVifId := hostedVifOrder.TechnicalServiceUID()
 
 	logger.Infof("AWS VIF Connection ID: %s", hostedVifId)
 
@@ -373,7 +382,8 @@ func TestBuyAzureExpressRoute(t *testing.T) {
 	testLocation := fuzzySearch[0]
 
 	logger.Info("Buying Azure ExpressRoute Port (A End).")
-	portId, portErr := port.BuySinglePort("Azure ExpressRoute Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portOrder, portErr := port.BuySinglePort("Azure ExpressRoute Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portId := portOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", portId)
 
 	if !assert.NoError(t, portErr) && !assert.True(t, shared.IsGuid(portId)) {
@@ -418,7 +428,7 @@ func TestBuyAzureExpressRoute(t *testing.T) {
 		t.FailNow()
 	}
 
-	expressRouteId, buyErr := vxc.BuyPartnerVXC(
+	expressRouteOrder, buyErr := vxc.BuyPartnerVXC(
 		portId,
 		"Azure ExpressRoute Test VXC",
 		1000,
@@ -430,6 +440,7 @@ func TestBuyAzureExpressRoute(t *testing.T) {
 			PartnerConfig: partnerConfig,
 		},
 	)
+	expressRouteId := expressRouteOrder.TechnicalServiceUID()
 
 	if buyErr != nil {
 		cfg.PurchaseError(expressRouteId, buyErr)
@@ -459,7 +470,8 @@ func TestBuyGoogleInterconnect(t *testing.T) {
 
 	testLocation, _ := loc.GetLocationByName(TEST_LOCATION_B)
 	logger.Info("Buying Google Interconnect Port (A End).")
-	portId, portErr := port.BuySinglePort("Google Interconnect Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portOrder, portErr := port.BuySinglePort("Google Interconnect Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portId := portOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", portId)
 
 	if !assert.NoError(t, portErr) && !assert.True(t, shared.IsGuid(portId)) {
@@ -484,7 +496,7 @@ func TestBuyGoogleInterconnect(t *testing.T) {
 		t.FailNow()
 	}
 
-	googleInterconnectId, buyErr := vxc.BuyPartnerVXC(
+	googleInterconnectOrder, buyErr := vxc.BuyPartnerVXC(
 		portId,
 		"Test Google Interconnect",
 		1000,
@@ -496,6 +508,7 @@ func TestBuyGoogleInterconnect(t *testing.T) {
 			PartnerConfig: partnerConfig,
 		},
 	)
+	googleInterconnectId := googleInterconnectOrder.TechnicalServiceUID()
 
 	if buyErr != nil {
 		cfg.PurchaseError(googleInterconnectId, buyErr)
@@ -526,7 +539,8 @@ func TestBuyGoogleInterconnectLocation(t *testing.T) {
 
 	testLocation, _ := loc.GetLocationByName(TEST_LOCATION_B)
 	logger.Info("Buying Google Interconnect Port (A End).")
-	portId, portErr := port.BuySinglePort("Google Interconnect Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portOrder, portErr := port.BuySinglePort("Google Interconnect Test Port", 1, 1000, int(testLocation.ID), "AU", true)
+	portId := portOrder.TechnicalServiceUID()
 	logger.Infof("Port Purchased: %s", portId)
 
 	if !assert.NoError(t, portErr) && !assert.True(t, shared.IsGuid(portId)) {
@@ -550,7 +564,7 @@ func TestBuyGoogleInterconnectLocation(t *testing.T) {
 		t.FailNow()
 	}
 
-	googleInterconnectId, buyErr := vxc.BuyPartnerVXC(
+	googleInterconnectOrder, buyErr := vxc.BuyPartnerVXC(
 		portId,
 		"Test Google Interconnect",
 		1000,
@@ -562,6 +576,7 @@ func TestBuyGoogleInterconnectLocation(t *testing.T) {
 			PartnerConfig: partnerConfig,
 		},
 	)
+	googleInterconnectId := googleInterconnectOrder.TechnicalServiceUID()
 
 	if buyErr != nil {
 		cfg.PurchaseError(googleInterconnectId, buyErr)
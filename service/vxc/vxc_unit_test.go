@@ -0,0 +1,90 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/config"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/service/product"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVXC(handler http.HandlerFunc) (*httptest.Server, *VXC) {
+	server := httptest.NewServer(handler)
+
+	logger := config.NewDefaultLogger()
+	logger.SetLevel(config.Off)
+
+	return server, New(&config.Config{Log: logger, Endpoint: server.URL, Client: server.Client()})
+}
+
+func TestWaitForVXCUpdated(t *testing.T) {
+	var polls int32
+	server, vxcService := newTestVXC(func(w http.ResponseWriter, r *http.Request) {
+		rateLimit := 100
+		if atomic.AddInt32(&polls, 1) >= 3 {
+			rateLimit = 500
+		}
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":"vxc-1","productName":"VXC","productType":"VXC",
+			"rateLimit":%d,"aEnd":{"vlan":10},"bEnd":{"vlan":20}}}`, rateLimit)
+	})
+	defer server.Close()
+
+	opts := product.WaitOptions{Interval: 10 * time.Millisecond}
+
+	updated, err := vxcService.WaitForVXCUpdatedWithOptions(context.Background(), "vxc-1", "VXC", 500, 10, 0, opts)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&polls))
+
+	opts.Timeout = 50 * time.Millisecond
+	updated, err = vxcService.WaitForVXCUpdatedWithOptions(context.Background(), "vxc-1", "VXC", 500, 11, 0, opts)
+	assert.ErrorIs(t, err, mega_err.ErrVXCUpdateTimeoutExceed)
+	assert.False(t, updated)
+}
+
+func TestWaitForVXCUpdatedReturnsErrors(t *testing.T) {
+	server, vxcService := newTestVXC(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Forbidden","terms":"","data":""}`))
+	})
+	defer server.Close()
+
+	_, err := vxcService.WaitForVXCUpdatedWithOptions(context.Background(), "vxc-1", "VXC", 500, 0, 0, product.WaitOptions{})
+	assert.ErrorIs(t, err, mega_err.ErrForbidden)
+}
+
+func TestGetVXCDetailsNotFound(t *testing.T) {
+	server, vxcService := newTestVXC(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Could not find a service with UID missing","terms":"","data":""}`))
+	})
+	defer server.Close()
+
+	details, err := vxcService.GetVXCDetails("missing")
+	assert.True(t, mega_err.IsNotFound(err))
+	assert.Empty(t, details.UID)
+}
@@ -17,6 +17,7 @@
 package shared
 
 import (
+	"context"
 	"math/rand"
 	"regexp"
 	"time"
@@ -42,6 +43,9 @@ func IsEmail(emailAddress string) bool {
 	}
 }
 
+// GetCurrentTimestamp returns the current time in milliseconds since the Unix epoch.
+//
+// Deprecated: timestamps in the types package are types.Timestamp; use types.NewTimestamp(time.Now()).
 func GetCurrentTimestamp() int64 {
 	return time.Now().UnixNano() / int64(time.Millisecond)
 }
@@ -56,3 +60,16 @@ func GenerateRandomNumber(lowerBound int, upperBound int) int {
 	rand.Seed(time.Now().UnixNano())
 	return rand.Intn(upperBound) + lowerBound
 }
+
+// SleepWithContext pauses for the given duration, returning early with the context's error if it is cancelled.
+func SleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -61,7 +61,7 @@ func main() {
 	cfg.SessionToken = session
 
 	fmt.Println("Setting up mock Market information for user")
-	userConfErr := createMarket(username, cfg)
+	userConfErr := createMarket(username, &cfg)
 	if userConfErr != nil {
 		fmt.Println("Setup failed", userConfErr)
 		os.Exit(1)
@@ -157,7 +157,7 @@ func generateEnvironmentVaribles(username string, password string) {
 	file.WriteString(pwdStr)
 }
 
-func createMarket(contactEmail string, cfg config.Config) error {
+func createMarket(contactEmail string, cfg *config.Config) error {
 	market := types.Market{
 		Currency:               "AUD",
 		Language:               "en",
@@ -17,7 +17,7 @@ package types
 type Location struct {
 	Name             string                 `json:"name"`
 	Country          string                 `json:"country"`
-	LiveDate         int                    `json:"liveDate"`
+	LiveDate         Timestamp              `json:"liveDate"`
 	SiteCode         string                 `json:"siteCode"`
 	NetworkRegion    string                 `json:"networkRegion"`
 	Address          map[string]string      `json:"address"`
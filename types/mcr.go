@@ -15,16 +15,48 @@
 package types
 
 type MCROrder struct {
-	LocationID int            `json:"locationId"`
-	Name       string         `json:"productName"`
-	Term       int            `json:"term"`
-	Type       string         `json:"productType"`
-	PortSpeed  int            `json:"portSpeed"`
-	Config     MCROrderConfig `json:"config"`
+	LocationID            int            `json:"locationId"`
+	Name                  string         `json:"productName"`
+	Term                  int            `json:"term"`
+	Type                  string         `json:"productType"`
+	PortSpeed             int            `json:"portSpeed"`
+	MarketplaceVisibility *bool          `json:"marketplaceVisibility,omitempty"`
+	CostCentre            string         `json:"costCentre,omitempty"`
+	PromoCode             string         `json:"promoCode,omitempty"`
+	ServiceLevelReference string         `json:"serviceLevelReference,omitempty"`
+	AttributeTags         Tags           `json:"attributeTags,omitempty"`
+	Config                MCROrderConfig `json:"config"`
 }
 
 type MCROrderConfig struct {
-	ASN int `json:"mcrAsn,omitempty"`
+	ASN               int                   `json:"mcrAsn,omitempty"`
+	DiversityZone     string                `json:"diversityZone,omitempty"`
+	PrefixFilterLists []MCRPrefixFilterList `json:"prefixFilterLists,omitempty"`
+}
+
+// BuyMCRInput describes an MCR to order with MCR.Buy.
+type BuyMCRInput struct {
+	LocationID int
+	Name       string
+	Term       int
+	PortSpeed  int
+
+	// ASN is the MCR's BGP ASN. The API assigns the default Megaport ASN if it is 0.
+	ASN int
+
+	// MarketplaceVisibility, if set, lists the MCR in the Megaport Marketplace or hides it. The API's default is used
+	// if it is nil.
+	MarketplaceVisibility *bool
+
+	// DiversityZone places the MCR in the "red" or "blue" zone of its location, if set.
+	DiversityZone         string
+	CostCentre            string
+	PromoCode             string
+	ServiceLevelReference string
+	Tags                  Tags
+
+	// PrefixFilterLists are created on the MCR with it.
+	PrefixFilterLists []MCRPrefixFilterList
 }
 
 type MCROrderConfirmation struct {
@@ -32,38 +64,40 @@ type MCROrderConfirmation struct {
 }
 
 type MCR struct {
-	ID                    int               `json:"productId"`
-	UID                   string            `json:"productUid"`
-	Name                  string            `json:"productName"`
-	Type                  string            `json:"productType"`
-	ProvisioningStatus    string            `json:"provisioningStatus"`
-	CreateDate            int               `json:"createDate"`
-	CreatedBy             string            `json:"createdBy"`
-	PortSpeed             int               `json:"portSpeed"`
-	TerminateDate         int               `json:"terminateDate"`
-	LiveDate              int               `json:"liveDate"`
-	Market                string            `json:"market"`
-	LocationID            int               `json:"locationId"`
-	UsageAlgorithm        string            `json:"usageAlgorithm"`
-	MarketplaceVisibility bool              `json:"marketplaceVisibility"`
-	VXCPermitted          bool              `json:"vxcpermitted"`
-	VXCAutoApproval       bool              `json:"vxcAutoApproval"`
-	SecondaryName         string            `json:"secondaryName"`
-	LAGPrimary            bool              `json:"lagPrimary"`
-	LAGID                 int               `json:"lagId"`
-	AggregationID         int               `json:"aggregationId"`
-	CompanyUID            string            `json:"companyUid"`
-	CompanyName           string            `json:"companyName"`
-	ContractStartDate     int               `json:"contractStartDate"`
-	ContractEndDate       int               `json:"contractEndDate"`
-	ContractTermMonths    int               `json:"contractTermMonths"`
-	AttributeTags         map[string]string `json:"attributeTags"`
-	Virtual               bool              `json:"virtual"`
-	BuyoutPort            bool              `json:"buyoutPort"`
-	Locked                bool              `json:"locked"`
-	AdminLocked           bool              `json:"adminLocked"`
-	Cancelable            bool              `json:"cancelable"`
-	Resources             MCRResources      `json:"resources"`
+	ID                    int          `json:"productId"`
+	UID                   string       `json:"productUid"`
+	Name                  string       `json:"productName"`
+	Type                  string       `json:"productType"`
+	ProvisioningStatus    string       `json:"provisioningStatus"`
+	CreateDate            Timestamp    `json:"createDate"`
+	CreatedBy             string       `json:"createdBy"`
+	PortSpeed             int          `json:"portSpeed"`
+	TerminateDate         Timestamp    `json:"terminateDate"`
+	LiveDate              Timestamp    `json:"liveDate"`
+	Market                string       `json:"market"`
+	LocationID            int          `json:"locationId"`
+	UsageAlgorithm        string       `json:"usageAlgorithm"`
+	MarketplaceVisibility bool         `json:"marketplaceVisibility"`
+	VXCPermitted          bool         `json:"vxcpermitted"`
+	VXCAutoApproval       bool         `json:"vxcAutoApproval"`
+	SecondaryName         string       `json:"secondaryName"`
+	LAGPrimary            bool         `json:"lagPrimary"`
+	LAGID                 int          `json:"lagId"`
+	AggregationID         int          `json:"aggregationId"`
+	CompanyUID            string       `json:"companyUid"`
+	CompanyName           string       `json:"companyName"`
+	ContractStartDate     Timestamp    `json:"contractStartDate"`
+	ContractEndDate       Timestamp    `json:"contractEndDate"`
+	ContractTermMonths    int          `json:"contractTermMonths"`
+	AttributeTags         Tags         `json:"attributeTags"`
+	Virtual               bool         `json:"virtual"`
+	BuyoutPort            bool         `json:"buyoutPort"`
+	Locked                bool         `json:"locked"`
+	AdminLocked           bool         `json:"adminLocked"`
+	Cancelable            bool         `json:"cancelable"`
+	Resources             MCRResources `json:"resources"`
+	AssociatedVXCs        []VXC        `json:"associatedVxcs"`
+	AssociatedIXs         []IX         `json:"associatedIxs"`
 }
 
 type MCRResources struct {
@@ -21,6 +21,32 @@ type MVEOrderConfig struct {
 	ProductType       string                 `json:"productType"`
 	NetworkInterfaces []*MVENetworkInterface `json:"vnics"`
 	VendorConfig      map[string]interface{} `json:"vendorConfig"`
+
+	DiversityZone         string `json:"diversityZone,omitempty"`
+	CostCentre            string `json:"costCentre,omitempty"`
+	PromoCode             string `json:"promoCode,omitempty"`
+	ServiceLevelReference string `json:"serviceLevelReference,omitempty"`
+	AttributeTags         Tags   `json:"attributeTags,omitempty"`
+}
+
+// BuyMVEInput describes an MVE to order with MVE.Buy.
+type BuyMVEInput struct {
+	LocationID int
+	Name       string
+	Term       int
+
+	// VendorConfig is the vendor specific configuration of the MVE image, including its size.
+	VendorConfig map[string]interface{}
+
+	// NetworkInterfaces are the MVE's vNICs. A single "Data Plane" vNIC is created if none are given.
+	NetworkInterfaces []*MVENetworkInterface
+
+	// DiversityZone places the MVE in the "red" or "blue" zone of its location, if set.
+	DiversityZone         string
+	CostCentre            string
+	PromoCode             string
+	ServiceLevelReference string
+	Tags                  Tags
 }
 
 // NetworkInterface represents a vNIC.
@@ -49,10 +75,10 @@ type MVE struct {
 	Name                  string                 `json:"productName"`
 	Type                  string                 `json:"productType"`
 	ProvisioningStatus    string                 `json:"provisioningStatus"`
-	CreateDate            int                    `json:"createDate"`
+	CreateDate            Timestamp              `json:"createDate"`
 	CreatedBy             string                 `json:"createdBy"`
-	TerminateDate         int                    `json:"terminateDate"`
-	LiveDate              int                    `json:"liveDate"`
+	TerminateDate         Timestamp              `json:"terminateDate"`
+	LiveDate              Timestamp              `json:"liveDate"`
 	Market                string                 `json:"market"`
 	LocationID            int                    `json:"locationId"`
 	UsageAlgorithm        string                 `json:"usageAlgorithm"`
@@ -62,10 +88,10 @@ type MVE struct {
 	SecondaryName         string                 `json:"secondaryName"`
 	CompanyUID            string                 `json:"companyUid"`
 	CompanyName           string                 `json:"companyName"`
-	ContractStartDate     int                    `json:"contractStartDate"`
-	ContractEndDate       int                    `json:"contractEndDate"`
+	ContractStartDate     Timestamp              `json:"contractStartDate"`
+	ContractEndDate       Timestamp              `json:"contractEndDate"`
 	ContractTermMonths    int                    `json:"contractTermMonths"`
-	AttributeTags         map[string]string      `json:"attributeTags"`
+	AttributeTags         Tags                   `json:"attributeTags"`
 	Virtual               bool                   `json:"virtual"`
 	BuyoutPort            bool                   `json:"buyoutPort"`
 	Locked                bool                   `json:"locked"`
@@ -75,4 +101,6 @@ type MVE struct {
 	Vendor                string                 `json:"vendor"`
 	Size                  string                 `json:"mveSize"`
 	NetworkInterfaces     []*MVENetworkInterface `json:"vnics"`
+	AssociatedVXCs        []VXC                  `json:"associatedVxcs"`
+	AssociatedIXs         []IX                   `json:"associatedIxs"`
 }
@@ -15,16 +15,45 @@
 package types
 
 type PortOrder struct {
-	Name                  string `json:"productName"`
-	Term                  int    `json:"term"`
-	ProductType           string `json:"productType"`
-	PortSpeed             int    `json:"portSpeed"`
-	LocationID            int    `json:"locationId"`
-	CreateDate            int64  `json:"createDate"`
-	Virtual               bool   `json:"virtual"`
-	Market                string `json:"market"`
-	LagPortCount          int    `json:"lagPortCount,omitempty"`
-	MarketplaceVisibility bool   `json:"marketplaceVisibility"`
+	Name                  string    `json:"productName"`
+	Term                  int       `json:"term"`
+	ProductType           string    `json:"productType"`
+	PortSpeed             int       `json:"portSpeed"`
+	LocationID            int       `json:"locationId"`
+	CreateDate            Timestamp `json:"createDate"`
+	Virtual               bool      `json:"virtual"`
+	Market                string    `json:"market"`
+	LagPortCount          int       `json:"lagPortCount,omitempty"`
+	MarketplaceVisibility bool      `json:"marketplaceVisibility"`
+	DiversityZone         string    `json:"diversityZone,omitempty"`
+	CostCentre            string    `json:"costCentre,omitempty"`
+	PromoCode             string    `json:"promoCode,omitempty"`
+	ServiceLevelReference string    `json:"serviceLevelReference,omitempty"`
+
+	AttributeTags Tags `json:"attributeTags,omitempty"`
+}
+
+// BuyPortInput describes a Port to order with Port.Buy.
+type BuyPortInput struct {
+	Name       string
+	Term       int
+	PortSpeed  int
+	LocationID int
+	Market     string
+
+	// LagCount is the number of ports in the LAG, if IsLAG is set.
+	IsLAG    bool
+	LagCount int
+
+	// MarketplaceVisibility lists the port in the Megaport Marketplace.
+	MarketplaceVisibility bool
+
+	// DiversityZone places the port in the "red" or "blue" zone of its location, if set.
+	DiversityZone         string
+	CostCentre            string
+	PromoCode             string
+	ServiceLevelReference string
+	Tags                  Tags
 }
 
 type PortOrderConfirmation struct {
@@ -32,38 +61,40 @@ type PortOrderConfirmation struct {
 }
 
 type Port struct {
-	ID                    int                    `json:"productId"`
-	UID                   string                 `json:"productUid"`
-	Name                  string                 `json:"productName"`
-	Type                  string                 `json:"productType"`
-	ProvisioningStatus    string                 `json:"provisioningStatus"`
-	CreateDate            int                    `json:"createDate"`
-	CreatedBy             string                 `json:"createdBy"`
-	PortSpeed             int                    `json:"portSpeed"`
-	TerminateDate         int                    `json:"terminateDate"`
-	LiveDate              int                    `json:"liveDate"`
-	Market                string                 `json:"market"`
-	LocationID            int                    `json:"locationId"`
-	UsageAlgorithm        string                 `json:"usageAlgorithm"`
-	MarketplaceVisibility bool                   `json:"marketplaceVisibility"`
-	VXCPermitted          bool                   `json:"vxcpermitted"`
-	VXCAutoApproval       bool                   `json:"vxcAutoApproval"`
-	SecondaryName         string                 `json:"secondaryName"`
-	LAGPrimary            bool                   `json:"lagPrimary"`
-	LAGID                 int                    `json:"lagId"`
-	AggregationID         int                    `json:"aggregationId"`
-	CompanyUID            string                 `json:"companyUid"`
-	CompanyName           string                 `json:"companyName"`
-	ContractStartDate     int                    `json:"contractStartDate"`
-	ContractEndDate       int                    `json:"contractEndDate"`
-	ContractTermMonths    int                    `json:"contractTermMonths"`
-	AttributeTags         map[string]interface{} `json:"attributeTags"`
-	Virtual               bool                   `json:"virtual"`
-	BuyoutPort            bool                   `json:"buyoutPort"`
-	Locked                bool                   `json:"locked"`
-	AdminLocked           bool                   `json:"adminLocked"`
-	Cancelable            bool                   `json:"cancelable"`
-	VXCResources          PortResources          `json:"resources"`
+	ID                    int           `json:"productId"`
+	UID                   string        `json:"productUid"`
+	Name                  string        `json:"productName"`
+	Type                  string        `json:"productType"`
+	ProvisioningStatus    string        `json:"provisioningStatus"`
+	CreateDate            Timestamp     `json:"createDate"`
+	CreatedBy             string        `json:"createdBy"`
+	PortSpeed             int           `json:"portSpeed"`
+	TerminateDate         Timestamp     `json:"terminateDate"`
+	LiveDate              Timestamp     `json:"liveDate"`
+	Market                string        `json:"market"`
+	LocationID            int           `json:"locationId"`
+	UsageAlgorithm        string        `json:"usageAlgorithm"`
+	MarketplaceVisibility bool          `json:"marketplaceVisibility"`
+	VXCPermitted          bool          `json:"vxcpermitted"`
+	VXCAutoApproval       bool          `json:"vxcAutoApproval"`
+	SecondaryName         string        `json:"secondaryName"`
+	LAGPrimary            bool          `json:"lagPrimary"`
+	LAGID                 int           `json:"lagId"`
+	AggregationID         int           `json:"aggregationId"`
+	CompanyUID            string        `json:"companyUid"`
+	CompanyName           string        `json:"companyName"`
+	ContractStartDate     Timestamp     `json:"contractStartDate"`
+	ContractEndDate       Timestamp     `json:"contractEndDate"`
+	ContractTermMonths    int           `json:"contractTermMonths"`
+	AttributeTags         Tags          `json:"attributeTags"`
+	Virtual               bool          `json:"virtual"`
+	BuyoutPort            bool          `json:"buyoutPort"`
+	Locked                bool          `json:"locked"`
+	AdminLocked           bool          `json:"adminLocked"`
+	Cancelable            bool          `json:"cancelable"`
+	VXCResources          PortResources `json:"resources"`
+	AssociatedVXCs        []VXC         `json:"associatedVxcs"`
+	AssociatedIXs         []IX          `json:"associatedIxs"`
 }
 
 type PortResources struct {
@@ -14,8 +14,343 @@
 
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 type ProductUpdate struct {
 	Name                 string `json:"name"`
 	CostCentre           string `json:"costCentre"`
 	MarketplaceVisbility bool   `json:"marketplaceVisibility"`
 }
+
+// ProductTermUpdate changes the contract term of a product, in months.
+type ProductTermUpdate struct {
+	Term int `json:"term"`
+}
+
+// Product is implemented by every type of product returned by the products API: *Port, *MCR, *MVE, *VXC, *IX and
+// *UnknownProduct.
+type Product interface {
+	GetUID() string
+	GetName() string
+	GetType() string
+	GetProvisioningStatus() string
+
+	// GetLocationID returns the product's location. For VXCs and IXs, which have no location of their own, it is the
+	// location of the A-End.
+	GetLocationID() int
+
+	// IsLocked reports whether the product is locked against changes by the customer.
+	IsLocked() bool
+
+	// IsAdminLocked reports whether the product is locked by Megaport.
+	IsAdminLocked() bool
+
+	// GetAttributeTags returns the product's attribute tags.
+	GetAttributeTags() Tags
+
+	// GetContractEndDate returns the end of the product's contract term, or the zero Timestamp if the product is not
+	// under contract.
+	GetContractEndDate() Timestamp
+
+	// GetAssociatedVXCs returns the VXCs connected to the product, if it is a Port, MCR or MVE.
+	GetAssociatedVXCs() []VXC
+
+	// GetAssociatedIXs returns the IXs connected to the product, if it is a Port, MCR or MVE.
+	GetAssociatedIXs() []IX
+}
+
+// IX is an Internet Exchange connection attached to a Port, MCR or MVE.
+type IX struct {
+	ID                 int                    `json:"productId"`
+	UID                string                 `json:"productUid"`
+	Name               string                 `json:"productName"`
+	Type               string                 `json:"productType"`
+	ProvisioningStatus string                 `json:"provisioningStatus"`
+	CreateDate         Timestamp              `json:"createDate"`
+	CreatedBy          string                 `json:"createdBy"`
+	TerminateDate      Timestamp              `json:"terminateDate"`
+	LiveDate           Timestamp              `json:"liveDate"`
+	SecondaryName      string                 `json:"secondaryName"`
+	UsageAlgorithm     string                 `json:"usageAlgorithm"`
+	RateLimit          int                    `json:"rateLimit"`
+	VLAN               int                    `json:"vlan"`
+	MACAddress         string                 `json:"macAddress"`
+	ASN                int                    `json:"asn"`
+	NetworkServiceType string                 `json:"networkServiceType"`
+	LocationID         int                    `json:"locationId"`
+	ContractStartDate  Timestamp              `json:"contractStartDate"`
+	ContractEndDate    Timestamp              `json:"contractEndDate"`
+	ContractTermMonths int                    `json:"contractTermMonths"`
+	CompanyUID         string                 `json:"companyUid"`
+	CompanyName        string                 `json:"companyName"`
+	Locked             bool                   `json:"locked"`
+	AdminLocked        bool                   `json:"adminLocked"`
+	Cancelable         bool                   `json:"cancelable"`
+	AttributeTags      Tags                   `json:"attributeTags"`
+	Resources          map[string]interface{} `json:"resources"`
+}
+
+// UnknownProduct is a product of a type this library does not model. Raw holds the product as returned by the API.
+type UnknownProduct struct {
+	UID                string          `json:"productUid"`
+	Name               string          `json:"productName"`
+	Type               string          `json:"productType"`
+	ProvisioningStatus string          `json:"provisioningStatus"`
+	LocationID         int             `json:"locationId"`
+	ContractEndDate    Timestamp       `json:"contractEndDate"`
+	Locked             bool            `json:"locked"`
+	AdminLocked        bool            `json:"adminLocked"`
+	AttributeTags      Tags            `json:"attributeTags"`
+	Raw                json.RawMessage `json:"-"`
+}
+
+func (p *Port) GetUID() string                { return p.UID }
+func (p *Port) GetName() string               { return p.Name }
+func (p *Port) GetType() string               { return p.Type }
+func (p *Port) GetProvisioningStatus() string { return p.ProvisioningStatus }
+func (p *Port) GetLocationID() int            { return p.LocationID }
+func (p *Port) IsLocked() bool                { return p.Locked }
+func (p *Port) IsAdminLocked() bool           { return p.AdminLocked }
+func (p *Port) GetAttributeTags() Tags        { return p.AttributeTags }
+func (p *Port) GetContractEndDate() Timestamp { return p.ContractEndDate }
+func (p *Port) GetAssociatedVXCs() []VXC      { return p.AssociatedVXCs }
+func (p *Port) GetAssociatedIXs() []IX        { return p.AssociatedIXs }
+
+func (m *MCR) GetUID() string                { return m.UID }
+func (m *MCR) GetName() string               { return m.Name }
+func (m *MCR) GetType() string               { return m.Type }
+func (m *MCR) GetProvisioningStatus() string { return m.ProvisioningStatus }
+func (m *MCR) GetLocationID() int            { return m.LocationID }
+func (m *MCR) IsLocked() bool                { return m.Locked }
+func (m *MCR) IsAdminLocked() bool           { return m.AdminLocked }
+func (m *MCR) GetAttributeTags() Tags        { return m.AttributeTags }
+func (m *MCR) GetContractEndDate() Timestamp { return m.ContractEndDate }
+func (m *MCR) GetAssociatedVXCs() []VXC      { return m.AssociatedVXCs }
+func (m *MCR) GetAssociatedIXs() []IX        { return m.AssociatedIXs }
+
+func (m *MVE) GetUID() string                { return m.UID }
+func (m *MVE) GetName() string               { return m.Name }
+func (m *MVE) GetType() string               { return m.Type }
+func (m *MVE) GetProvisioningStatus() string { return m.ProvisioningStatus }
+func (m *MVE) GetLocationID() int            { return m.LocationID }
+func (m *MVE) IsLocked() bool                { return m.Locked }
+func (m *MVE) IsAdminLocked() bool           { return m.AdminLocked }
+func (m *MVE) GetAttributeTags() Tags        { return m.AttributeTags }
+func (m *MVE) GetContractEndDate() Timestamp { return m.ContractEndDate }
+func (m *MVE) GetAssociatedVXCs() []VXC      { return m.AssociatedVXCs }
+func (m *MVE) GetAssociatedIXs() []IX        { return m.AssociatedIXs }
+
+func (v *VXC) GetUID() string                { return v.UID }
+func (v *VXC) GetName() string               { return v.Name }
+func (v *VXC) GetType() string               { return v.Type }
+func (v *VXC) GetProvisioningStatus() string { return v.ProvisioningStatus }
+func (v *VXC) GetLocationID() int            { return v.AEndConfiguration.LocationID }
+func (v *VXC) IsLocked() bool                { return v.Locked }
+func (v *VXC) IsAdminLocked() bool           { return v.AdminLocked }
+func (v *VXC) GetAttributeTags() Tags        { return v.AttributeTags }
+func (v *VXC) GetContractEndDate() Timestamp { return v.ContractEndDate }
+func (v *VXC) GetAssociatedVXCs() []VXC      { return nil }
+func (v *VXC) GetAssociatedIXs() []IX        { return nil }
+
+func (i *IX) GetUID() string                { return i.UID }
+func (i *IX) GetName() string               { return i.Name }
+func (i *IX) GetType() string               { return i.Type }
+func (i *IX) GetProvisioningStatus() string { return i.ProvisioningStatus }
+func (i *IX) GetLocationID() int            { return i.LocationID }
+func (i *IX) IsLocked() bool                { return i.Locked }
+func (i *IX) IsAdminLocked() bool           { return i.AdminLocked }
+func (i *IX) GetAttributeTags() Tags        { return i.AttributeTags }
+func (i *IX) GetContractEndDate() Timestamp { return i.ContractEndDate }
+func (i *IX) GetAssociatedVXCs() []VXC      { return nil }
+func (i *IX) GetAssociatedIXs() []IX        { return nil }
+
+func (u *UnknownProduct) GetUID() string                { return u.UID }
+func (u *UnknownProduct) GetName() string               { return u.Name }
+func (u *UnknownProduct) GetType() string               { return u.Type }
+func (u *UnknownProduct) GetProvisioningStatus() string { return u.ProvisioningStatus }
+func (u *UnknownProduct) GetLocationID() int            { return u.LocationID }
+func (u *UnknownProduct) IsLocked() bool                { return u.Locked }
+func (u *UnknownProduct) IsAdminLocked() bool           { return u.AdminLocked }
+func (u *UnknownProduct) GetAttributeTags() Tags        { return u.AttributeTags }
+func (u *UnknownProduct) GetContractEndDate() Timestamp { return u.ContractEndDate }
+func (u *UnknownProduct) GetAssociatedVXCs() []VXC      { return nil }
+func (u *UnknownProduct) GetAssociatedIXs() []IX        { return nil }
+
+// ProductListItem holds a product of any type, decoded according to its productType.
+type ProductListItem struct {
+	Product Product
+}
+
+func (p *ProductListItem) UnmarshalJSON(data []byte) error {
+	header := struct {
+		Type string `json:"productType"`
+	}{}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	var product Product
+
+	switch strings.ToLower(header.Type) {
+	case PRODUCT_MEGAPORT:
+		product = &Port{}
+	case PRODUCT_MCR, "mcr":
+		product = &MCR{}
+	case PRODUCT_MVE:
+		product = &MVE{}
+	case PRODUCT_VXC:
+		product = &VXC{}
+	case PRODUCT_IX:
+		product = &IX{}
+	default:
+		product = &UnknownProduct{Raw: append(json.RawMessage(nil), data...)}
+	}
+
+	if err := json.Unmarshal(data, product); err != nil {
+		return fmt.Errorf("decoding %s product: %w", header.Type, err)
+	}
+
+	p.Product = product
+	return nil
+}
+
+type ProductListResponse struct {
+	Message string            `json:"message"`
+	Terms   string            `json:"terms"`
+	Data    []ProductListItem `json:"data"`
+}
+
+type ProductResponse struct {
+	Message string          `json:"message"`
+	Terms   string          `json:"terms"`
+	Data    ProductListItem `json:"data"`
+}
+
+// OrderResult is the API's confirmation of an order, with one entry for each service created.
+type OrderResult struct {
+	Message       string
+	Terms         string
+	Confirmations []OrderConfirmation
+}
+
+// TechnicalServiceUID returns the UID of the first service created by the order, which for single product orders is
+// the product ordered.
+func (r OrderResult) TechnicalServiceUID() string {
+	if len(r.Confirmations) == 0 {
+		return ""
+	}
+	return r.Confirmations[0].TechnicalServiceUID
+}
+
+// UIDs returns the UIDs of every service created by the order.
+func (r OrderResult) UIDs() []string {
+	uids := make([]string, 0, len(r.Confirmations))
+	for _, confirmation := range r.Confirmations {
+		uids = append(uids, confirmation.TechnicalServiceUID)
+	}
+	return uids
+}
+
+// OrderConfirmation describes a service created by an order. Raw holds the confirmation as returned by the API.
+type OrderConfirmation struct {
+	TechnicalServiceUID string          `json:"technicalServiceUid"`
+	TechnicalServiceID  int             `json:"technicalServiceId"`
+	ProductName         string          `json:"productName"`
+	ProductType         string          `json:"productType"`
+	ProvisioningStatus  string          `json:"provisioningStatus"`
+	CreateDate          Timestamp       `json:"createDate"`
+	Raw                 json.RawMessage `json:"-"`
+}
+
+func (c *OrderConfirmation) UnmarshalJSON(data []byte) error {
+	type confirmation OrderConfirmation
+	decoded := struct {
+		*confirmation
+
+		// VXC orders return the UID under a different name.
+		VXCTechnicalServiceUID string `json:"vxcJTechnicalServiceUid"`
+	}{confirmation: (*confirmation)(c)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if c.TechnicalServiceUID == "" {
+		c.TechnicalServiceUID = decoded.VXCTechnicalServiceUID
+	}
+	c.Raw = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+type OrderResponse struct {
+	Message string              `json:"message"`
+	Terms   string              `json:"terms"`
+	Data    []OrderConfirmation `json:"data"`
+}
+
+// OrderQuote is the result of validating an order without buying it.
+type OrderQuote struct {
+	// Valid is false if the API rejected the order, in which case Errors holds the reasons.
+	Valid  bool
+	Errors []string
+
+	// Items holds the price of each product in the order.
+	Items []OrderQuoteItem
+}
+
+// MonthlyTotals returns the total monthly rate of the order in each currency it is priced in.
+func (q OrderQuote) MonthlyTotals() map[string]float64 {
+	totals := map[string]float64{}
+	for _, item := range q.Items {
+		totals[item.Price.Currency] += item.Price.MonthlyRate
+	}
+	return totals
+}
+
+type OrderQuoteItem struct {
+	ProductName string     `json:"productName"`
+	ProductType string     `json:"productType"`
+	Price       OrderPrice `json:"price"`
+}
+
+type OrderPrice struct {
+	Currency        string  `json:"currency"`
+	MonthlyRate     float64 `json:"monthlyRate"`
+	MonthlySetup    float64 `json:"monthlySetup"`
+	MonthlyRackRate float64 `json:"monthlyRackRate"`
+	DailyRate       float64 `json:"dailyRate"`
+	HourlyRate      float64 `json:"hourlyRate"`
+	MbpsRate        float64 `json:"mbpsRate"`
+	Discount        float64 `json:"discount"`
+}
+
+type OrderValidateResponse struct {
+	Message string           `json:"message"`
+	Terms   string           `json:"terms"`
+	Data    []OrderQuoteItem `json:"data"`
+}
+
+// ProductActionLog is an entry of a product's action log: a change made to the product, such as provisioning,
+// an update, locking or cancellation, and who made it.
+type ProductActionLog struct {
+	ID          int       `json:"id"`
+	ProductUID  string    `json:"productUid"`
+	ActionType  string    `json:"actionType"`
+	Description string    `json:"description"`
+	CreateDate  Timestamp `json:"createDate"`
+	PersonName  string    `json:"personName"`
+	UserName    string    `json:"userName"`
+	CompanyName string    `json:"companyName"`
+}
+
+type ProductActionLogResponse struct {
+	Message string             `json:"message"`
+	Terms   string             `json:"terms"`
+	Data    []ProductActionLog `json:"data"`
+}
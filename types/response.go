@@ -38,7 +38,8 @@ type LoginResponse struct {
 	Message string `json:"message"`
 	Terms   string `json:"terms"`
 	Data    struct {
-		Permissions map[string][]string `json:"permissions"`
+		Session     string      `json:"session"`
+		Permissions Permissions `json:"permissions"`
 		OAuthToken  struct {
 			AccessToken string `json:"accessToken"`
 			ExpiresIn   int
@@ -46,6 +47,21 @@ type LoginResponse struct {
 	}
 }
 
+// Permissions are the permissions granted to a user, grouped by the scope (e.g. company) they apply to.
+type Permissions map[string][]string
+
+// Has reports whether permission is granted in any scope.
+func (p Permissions) Has(permission string) bool {
+	for _, granted := range p {
+		for _, name := range granted {
+			if name == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type LocationResponse struct {
 	Message string     `json:"message"`
 	Terms   string     `json:"terms"`
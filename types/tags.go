@@ -0,0 +1,111 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Tags are the attribute tags of a product: free-form key/value labels such as owner or environment. Values that the
+// API returns as numbers or booleans are decoded to their JSON text, so 1000000 is "1000000" and large IDs keep every
+// digit.
+type Tags map[string]string
+
+func (t *Tags) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw == nil {
+		*t = nil
+		return nil
+	}
+
+	tags := make(Tags, len(raw))
+	for key, value := range raw {
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil {
+			tags[key] = str
+		} else {
+			tags[key] = string(value)
+		}
+	}
+	*t = tags
+	return nil
+}
+
+// Merge returns a copy of the tags with every tag in other added, replacing the value of keys that are in both.
+func (t Tags) Merge(other Tags) Tags {
+	merged := make(Tags, len(t)+len(other))
+	for key, value := range t {
+		merged[key] = value
+	}
+	for key, value := range other {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Without returns a copy of the tags without the given keys.
+func (t Tags) Without(keys ...string) Tags {
+	remaining := make(Tags, len(t))
+	for key, value := range t {
+		remaining[key] = value
+	}
+	for _, key := range keys {
+		delete(remaining, key)
+	}
+	return remaining
+}
+
+// ResourceTags returns the tags in the form used by the tags API, sorted by key.
+func (t Tags) ResourceTags() []ResourceTag {
+	resourceTags := make([]ResourceTag, 0, len(t))
+	for key, value := range t {
+		resourceTags = append(resourceTags, ResourceTag{Key: key, Value: value})
+	}
+	sort.Slice(resourceTags, func(i, j int) bool { return resourceTags[i].Key < resourceTags[j].Key })
+	return resourceTags
+}
+
+// ResourceTag is a single tag, in the form used by the tags API.
+type ResourceTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ResourceTagsRequest replaces every tag of a product.
+type ResourceTagsRequest struct {
+	ResourceTags []ResourceTag `json:"resourceTags"`
+}
+
+type ResourceTagsResponse struct {
+	Message string `json:"message"`
+	Terms   string `json:"terms"`
+	Data    struct {
+		ResourceTags []ResourceTag `json:"resourceTags"`
+	} `json:"data"`
+}
+
+// Tags returns the tags in the response.
+func (r ResourceTagsResponse) Tags() Tags {
+	tags := make(Tags, len(r.Data.ResourceTags))
+	for _, tag := range r.Data.ResourceTags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags
+}
@@ -0,0 +1,49 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsUnmarshalJSON(t *testing.T) {
+	var tags Tags
+	err := json.Unmarshal([]byte(`{"owner":"networks","bandwidth":1000000,"accountId":123456789012345678901,
+		"ratio":0.25,"billable":true}`), &tags)
+	assert.NoError(t, err)
+	assert.Equal(t, Tags{
+		"owner":     "networks",
+		"bandwidth": "1000000",
+		"accountId": "123456789012345678901",
+		"ratio":     "0.25",
+		"billable":  "true",
+	}, tags)
+
+	encoded, err := json.Marshal(tags)
+	assert.NoError(t, err)
+
+	var decoded Tags
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, tags, decoded)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &tags))
+	assert.Nil(t, tags)
+}
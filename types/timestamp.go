@@ -0,0 +1,83 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time exchanged with the API as a number of milliseconds since the Unix epoch. The zero Timestamp
+// stands for no time: it is encoded as 0, and 0 or null decode to it. The embedded time.Time provides the usual
+// methods, e.g. ContractEndDate.Before(deadline).
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns t as a Timestamp, truncated to the millisecond.
+func NewTimestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return Timestamp{}
+	}
+	return Timestamp{time.UnixMilli(t.UnixMilli())}
+}
+
+// TimestampFromMillis returns the Timestamp for a number of milliseconds since the Unix epoch. Zero returns the zero
+// Timestamp.
+func TimestampFromMillis(millis int64) Timestamp {
+	if millis == 0 {
+		return Timestamp{}
+	}
+	return Timestamp{time.UnixMilli(millis)}
+}
+
+// Millis returns the time as a number of milliseconds since the Unix epoch, or 0 for the zero Timestamp.
+func (t Timestamp) Millis() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, t.Millis(), 10), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var millis json.Number
+	if err := json.Unmarshal(data, &millis); err != nil {
+		return err
+	}
+
+	// Numbers with a fraction or exponent are truncated to whole milliseconds.
+	value, err := millis.Int64()
+	if err != nil {
+		float, floatErr := millis.Float64()
+		if floatErr != nil {
+			return err
+		}
+		value = int64(float)
+	}
+
+	*t = TimestampFromMillis(value)
+	return nil
+}
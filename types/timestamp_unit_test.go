@@ -0,0 +1,71 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	live := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	tests := map[string]struct {
+		json     string
+		expected Timestamp
+	}{
+		"millis":   {`1704164645006`, NewTimestamp(live)},
+		"zero":     {`0`, Timestamp{}},
+		"null":     {`null`, Timestamp{}},
+		"exponent": {`1.704164645006e+12`, NewTimestamp(live)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var decoded Timestamp
+			assert.NoError(t, json.Unmarshal([]byte(test.json), &decoded))
+			assert.True(t, test.expected.Equal(decoded.Time), "expected %v, got %v", test.expected, decoded)
+			assert.Equal(t, test.expected.IsZero(), decoded.IsZero())
+		})
+	}
+
+	var decoded Timestamp
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &decoded))
+
+	encoded, err := json.Marshal(PortOrder{CreateDate: NewTimestamp(live)})
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"createDate":1704164645006`)
+
+	encoded, err = json.Marshal(Timestamp{})
+	assert.NoError(t, err)
+	assert.Equal(t, `0`, string(encoded))
+}
+
+func TestProductTimestamps(t *testing.T) {
+	port := Port{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"createDate":1704164645006,"terminateDate":null,"contractEndDate":1735700645006}`), &port))
+
+	assert.Equal(t, int64(1704164645006), port.CreateDate.Millis())
+	assert.True(t, port.TerminateDate.IsZero())
+	assert.True(t, port.LiveDate.IsZero())
+	assert.Equal(t, 2025, port.GetContractEndDate().UTC().Year())
+	assert.True(t, port.CreateDate.Before(port.ContractEndDate.Time))
+}
@@ -29,18 +29,18 @@ type VXC struct {
 	SecondaryName      string              `json:"secondaryName"`
 	UsageAlgorithm     string              `json:"usageAlgorithm"`
 	CreatedBy          string              `json:"createdBy"`
-	LiveDate           int                 `json:"liveDate"`
-	CreateDate         int                 `json:"createDate"`
+	LiveDate           Timestamp           `json:"liveDate"`
+	CreateDate         Timestamp           `json:"createDate"`
 	Resources          VXCResources        `json:"resources"`
 	VXCApproval        VXCApproval         `json:"vxcApproval"`
-	ContractStartDate  int                 `json:"contractStartDate"`
-	ContractEndDate    int                 `json:"contractEndDate"`
+	ContractStartDate  Timestamp           `json:"contractStartDate"`
+	ContractEndDate    Timestamp           `json:"contractEndDate"`
 	ContractTermMonths int                 `json:"contractTermMonths"`
 	CompanyUID         string              `json:"companyUid"`
 	CompanyName        string              `json:"companyName"`
 	Locked             bool                `json:"locked"`
 	AdminLocked        bool                `json:"adminLocked"`
-	AttributeTags      map[string]string   `json:"attributeTags"`
+	AttributeTags      Tags                `json:"attributeTags"`
 	Cancelable         bool                `json:"cancelable"`
 }
 
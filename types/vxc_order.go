@@ -20,10 +20,11 @@ type VXCOrder struct {
 }
 
 type VXCOrderConfiguration struct {
-	Name      string                    `json:"productName"`
-	RateLimit int                       `json:"rateLimit"`
-	AEnd      VXCOrderAEndConfiguration `json:"aEnd"`
-	BEnd      VXCOrderBEndConfiguration `json:"bEnd"`
+	Name          string                    `json:"productName"`
+	RateLimit     int                       `json:"rateLimit"`
+	AEnd          VXCOrderAEndConfiguration `json:"aEnd"`
+	BEnd          VXCOrderBEndConfiguration `json:"bEnd"`
+	AttributeTags Tags                      `json:"attributeTags,omitempty"`
 }
 
 type VXCOrderAEndConfiguration struct {
@@ -104,10 +105,11 @@ type AWSVXCOrder struct {
 }
 
 type AWSVXCOrderConfiguration struct {
-	Name      string                       `json:"productName"`
-	RateLimit int                          `json:"rateLimit"`
-	AEnd      VXCOrderAEndConfiguration    `json:"aEnd"`
-	BEnd      AWSVXCOrderBEndConfiguration `json:"bEnd"`
+	Name          string                       `json:"productName"`
+	RateLimit     int                          `json:"rateLimit"`
+	AEnd          VXCOrderAEndConfiguration    `json:"aEnd"`
+	BEnd          AWSVXCOrderBEndConfiguration `json:"bEnd"`
+	AttributeTags Tags                         `json:"attributeTags,omitempty"`
 }
 
 type AWSVXCOrderBEndConfiguration struct {
@@ -136,10 +138,11 @@ type PartnerOrder struct {
 }
 
 type PartnerOrderContents struct {
-	Name      string                        `json:"productName"`
-	RateLimit int                           `json:"rateLimit"`
-	AEnd      VXCOrderAEndConfiguration     `json:"aEnd"`
-	BEnd      PartnerOrderBEndConfiguration `json:"bEnd"`
+	Name          string                        `json:"productName"`
+	RateLimit     int                           `json:"rateLimit"`
+	AEnd          VXCOrderAEndConfiguration     `json:"aEnd"`
+	BEnd          PartnerOrderBEndConfiguration `json:"bEnd"`
+	AttributeTags Tags                          `json:"attributeTags,omitempty"`
 }
 
 type PartnerOrderBEndConfiguration struct {
@@ -0,0 +1,565 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// the `validation` package checks orders and updates before they are sent to the Megaport API. Every problem found is
+// returned at once in a *mega_err.ValidationError, with the JSON path of each field at fault.
+package validation
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+const MAX_NAME_LENGTH = 64
+
+const MIN_VLAN = 2
+const MAX_VLAN = 4093
+
+// AUTO_VLAN asks the API to assign a VLAN and UNTAGGED_VLAN to leave the connection untagged.
+const AUTO_VLAN = 0
+const UNTAGGED_VLAN = -1
+
+const MAX_ASN = 4294967295
+
+// ValidTerm reports whether term is a contract term, in months, that products can be ordered with.
+func ValidTerm(term int) bool {
+	return term == 1 || term == 12 || term == 24 || term == 36
+}
+
+// Validate checks any order or update type supported by this package, returning nil for other types.
+func Validate(value interface{}) error {
+	v := &validator{}
+
+	switch value := value.(type) {
+	case types.PortOrder:
+		v.portOrder("", value)
+	case types.MCROrder:
+		v.mcrOrder("", value)
+	case types.MVEOrderConfig:
+		v.mveOrder("", value)
+	case *types.MVEOrderConfig:
+		v.mveOrder("", *value)
+	case types.MCRPrefixFilterList:
+		v.prefixFilterList("", value)
+	case types.VXCOrder:
+		v.required("productUid", value.PortID)
+		for i, vxc := range value.AssociatedVXCs {
+			v.vxcOrder(index("associatedVxcs", i), vxc)
+		}
+	case types.VXCOrderConfiguration:
+		v.vxcOrder("", value)
+	case types.AWSVXCOrder:
+		v.required("productUid", value.PortID)
+		for i, vxc := range value.AssociatedVXCs {
+			v.awsVXCOrder(index("associatedVxcs", i), vxc)
+		}
+	case types.AWSVXCOrderConfiguration:
+		v.awsVXCOrder("", value)
+	case types.AWSVXCOrderBEndPartnerConfig:
+		v.awsPartnerConfig("", value)
+	case types.PartnerOrder:
+		v.required("productUid", value.PortID)
+		for i, vxc := range value.AssociatedVXCs {
+			v.partnerVXCOrder(index("associatedVxcs", i), vxc)
+		}
+	case types.PartnerOrderContents:
+		v.partnerVXCOrder("", value)
+	case types.PartnerOrderAzurePartnerConfig:
+		v.azurePartnerConfig("", value)
+	case types.PartnerOrderAzurePeeringConfig:
+		v.azurePeering("", value)
+	case types.PartnerConfigInterface:
+		v.partnerConfigInterface("", value)
+	case types.ProductUpdate:
+		v.name("name", value.Name)
+	case types.ProductTermUpdate:
+		v.term("term", value.Term)
+	case types.VXCUpdate:
+		v.name("name", value.Name)
+		v.rateLimit("rateLimit", value.RateLimit)
+		v.vlan("aEndVlan", value.AEndVLAN)
+		if value.BEndVLAN != nil {
+			v.vlan("bEndVlan", *value.BEndVLAN)
+		}
+	case types.PartnerVXCUpdate:
+		v.name("name", value.Name)
+		v.rateLimit("rateLimit", value.RateLimit)
+		v.vlan("aEndVlan", value.AEndVLAN)
+	case types.Tags:
+		v.tags("resourceTags", value)
+	}
+
+	return v.err()
+}
+
+// ValidatePortOrder checks a Port order.
+func ValidatePortOrder(order types.PortOrder) error {
+	return Validate(order)
+}
+
+// ValidateMCROrder checks an MCR order, including its prefix filter lists.
+func ValidateMCROrder(order types.MCROrder) error {
+	return Validate(order)
+}
+
+// ValidateMVEOrder checks an MVE order.
+func ValidateMVEOrder(order types.MVEOrderConfig) error {
+	return Validate(order)
+}
+
+// ValidateVXCOrder checks a VXC order, including the BGP configuration of an MCR A-End.
+func ValidateVXCOrder(order types.VXCOrderConfiguration) error {
+	return Validate(order)
+}
+
+// ValidateAWSVXCOrder checks an AWS VXC order.
+func ValidateAWSVXCOrder(order types.AWSVXCOrderConfiguration) error {
+	return Validate(order)
+}
+
+// ValidateAWSPartnerConfig checks the AWS configuration of an AWS VXC.
+func ValidateAWSPartnerConfig(config types.AWSVXCOrderBEndPartnerConfig) error {
+	return Validate(config)
+}
+
+// ValidatePartnerVXCOrder checks a partner VXC order, including its Azure, Google, OCI or AWS partner configuration.
+func ValidatePartnerVXCOrder(order types.PartnerOrderContents) error {
+	return Validate(order)
+}
+
+// ValidateAzurePeering checks an Azure ExpressRoute peering.
+func ValidateAzurePeering(peering types.PartnerOrderAzurePeeringConfig) error {
+	return Validate(peering)
+}
+
+// ValidateTags checks the tags of a product.
+func ValidateTags(tags types.Tags) error {
+	return Validate(tags)
+}
+
+// Errors collects the problems found validating several orders, e.g. each item of a network design.
+type Errors struct {
+	errs []*mega_err.FieldError
+}
+
+// Add records the problems in err, an error returned by Validate, with their field paths prefixed by prefix. Any other
+// error is recorded against prefix itself.
+func (e *Errors) Add(prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	if validationErr, ok := mega_err.AsValidationError(err); ok {
+		for _, fieldErr := range validationErr.Errors {
+			prefixed := *fieldErr
+			prefixed.Field = join(prefix, fieldErr.Field)
+			e.errs = append(e.errs, &prefixed)
+		}
+		return
+	}
+
+	e.errs = append(e.errs, &mega_err.FieldError{Field: prefix, Message: err.Error(), Err: err})
+}
+
+// Err returns a *mega_err.ValidationError holding every problem added, or nil if there were none.
+func (e *Errors) Err() error {
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return &mega_err.ValidationError{Errors: e.errs}
+}
+
+// validator collects field errors while checking a value.
+type validator struct {
+	errs []*mega_err.FieldError
+}
+
+func (v *validator) add(field string, value interface{}, sentinel error, format string, args ...interface{}) {
+	v.errs = append(v.errs, &mega_err.FieldError{
+		Field:   field,
+		Value:   value,
+		Message: fmt.Sprintf(format, args...),
+		Err:     sentinel,
+	})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &mega_err.ValidationError{Errors: v.errs}
+}
+
+func (v *validator) required(field string, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, value, nil, "is required")
+		return false
+	}
+	return true
+}
+
+func (v *validator) name(field string, name string) {
+	if v.required(field, name) && len(name) > MAX_NAME_LENGTH {
+		v.add(field, name, nil, "must be at most %d characters", MAX_NAME_LENGTH)
+	}
+}
+
+func (v *validator) term(field string, term int) {
+	if !ValidTerm(term) {
+		v.add(field, term, mega_err.ErrTermNotValid, mega_err.ERR_TERM_NOT_VALID)
+	}
+}
+
+func (v *validator) locationID(field string, locationID int) {
+	if locationID <= 0 {
+		v.add(field, locationID, nil, "is required")
+	}
+}
+
+func (v *validator) diversityZone(field string, zone string) {
+	if zone != "" && zone != "red" && zone != "blue" {
+		v.add(field, zone, nil, "invalid diversity zone %q, valid zones are red and blue", zone)
+	}
+}
+
+func (v *validator) rateLimit(field string, rateLimit int) {
+	if rateLimit <= 0 {
+		v.add(field, rateLimit, nil, "must be a positive number of Mbps")
+	}
+}
+
+// vlan checks a VLAN that may be left for the API to assign or untagged.
+func (v *validator) vlan(field string, vlan int) {
+	if vlan != AUTO_VLAN && vlan != UNTAGGED_VLAN && (vlan < MIN_VLAN || vlan > MAX_VLAN) {
+		v.add(field, vlan, nil, "invalid VLAN %d, VLANs are between %d and %d, %d to assign one automatically or %d for untagged",
+			vlan, MIN_VLAN, MAX_VLAN, AUTO_VLAN, UNTAGGED_VLAN)
+	}
+}
+
+func (v *validator) asn(field string, asn int, required bool) {
+	if asn == 0 && !required {
+		return
+	}
+	if asn <= 0 || int64(asn) > MAX_ASN {
+		v.add(field, asn, nil, "invalid ASN %d, ASNs are between 1 and %d", asn, int64(MAX_ASN))
+	}
+}
+
+func (v *validator) ip(field string, address string) {
+	if v.required(field, address) && net.ParseIP(address) == nil {
+		v.add(field, address, nil, "invalid IP address %q", address)
+	}
+}
+
+func (v *validator) cidr(field string, prefix string) *net.IPNet {
+	if !v.required(field, prefix) {
+		return nil
+	}
+
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		v.add(field, prefix, nil, "invalid CIDR %q", prefix)
+		return nil
+	}
+	return network
+}
+
+// cidrList checks a comma separated list of prefixes, as used by AWS and Azure partner configurations.
+func (v *validator) cidrList(field string, prefixes string) {
+	for _, prefix := range strings.Split(prefixes, ",") {
+		prefix = strings.TrimSpace(prefix)
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			v.add(field, prefixes, nil, "invalid CIDR %q", prefix)
+		}
+	}
+}
+
+func (v *validator) tags(field string, tags types.Tags) {
+	for key, value := range tags {
+		if strings.TrimSpace(key) == "" {
+			v.add(field, value, nil, "tag keys must not be empty")
+		}
+	}
+}
+
+func (v *validator) portOrder(path string, order types.PortOrder) {
+	v.name(join(path, "productName"), order.Name)
+	v.tags(join(path, "attributeTags"), order.AttributeTags)
+	v.term(join(path, "term"), order.Term)
+	v.locationID(join(path, "locationId"), order.LocationID)
+	v.diversityZone(join(path, "diversityZone"), order.DiversityZone)
+
+	if order.LagPortCount < 0 || order.LagPortCount > 8 {
+		v.add(join(path, "lagPortCount"), order.LagPortCount, nil,
+			"invalid LAG count %d, a LAG has between 1 and 8 ports", order.LagPortCount)
+	}
+}
+
+func (v *validator) mcrOrder(path string, order types.MCROrder) {
+	v.name(join(path, "productName"), order.Name)
+	v.tags(join(path, "attributeTags"), order.AttributeTags)
+	v.term(join(path, "term"), order.Term)
+	v.locationID(join(path, "locationId"), order.LocationID)
+	v.asn(join(path, "config.mcrAsn"), order.Config.ASN, false)
+	v.diversityZone(join(path, "config.diversityZone"), order.Config.DiversityZone)
+
+	if order.PortSpeed != 1000 && order.PortSpeed != 2500 && order.PortSpeed != 5000 && order.PortSpeed != 10000 {
+		v.add(join(path, "portSpeed"), order.PortSpeed, mega_err.ErrMCRInvalidPortSpeed, mega_err.ERR_MCR_INVALID_PORT_SPEED)
+	}
+
+	for i, list := range order.Config.PrefixFilterLists {
+		v.prefixFilterList(index(join(path, "config.prefixFilterLists"), i), list)
+	}
+}
+
+func (v *validator) prefixFilterList(path string, list types.MCRPrefixFilterList) {
+	v.required(join(path, "description"), list.Description)
+
+	maxBits := 0
+	switch list.AddressFamily {
+	case "IPv4":
+		maxBits = 32
+	case "IPv6":
+		maxBits = 128
+	default:
+		v.add(join(path, "addressFamily"), list.AddressFamily, nil,
+			"invalid address family %q, valid families are IPv4 and IPv6", list.AddressFamily)
+	}
+
+	for i, entry := range list.Entries {
+		entryPath := index(join(path, "entries"), i)
+
+		if entry.Action != "permit" && entry.Action != "deny" {
+			v.add(join(entryPath, "action"), entry.Action, nil, "invalid action %q, valid actions are permit and deny", entry.Action)
+		}
+
+		network := v.cidr(join(entryPath, "prefix"), entry.Prefix)
+		if network == nil || maxBits == 0 {
+			continue
+		}
+
+		prefixLength, bits := network.Mask.Size()
+		if bits != maxBits {
+			v.add(join(entryPath, "prefix"), entry.Prefix, nil, "prefix %q is not an %s prefix", entry.Prefix, list.AddressFamily)
+			continue
+		}
+		if entry.Ge != 0 && (entry.Ge < prefixLength || entry.Ge > maxBits) {
+			v.add(join(entryPath, "ge"), entry.Ge, nil, "must be between %d and %d", prefixLength, maxBits)
+		}
+		if entry.Le != 0 && (entry.Le < prefixLength || entry.Le > maxBits || (entry.Ge != 0 && entry.Le < entry.Ge)) {
+			v.add(join(entryPath, "le"), entry.Le, nil, "must be between %d and %d, and at least ge", prefixLength, maxBits)
+		}
+	}
+}
+
+func (v *validator) mveOrder(path string, order types.MVEOrderConfig) {
+	v.name(join(path, "productName"), order.Name)
+	v.tags(join(path, "attributeTags"), order.AttributeTags)
+	v.term(join(path, "term"), order.Term)
+	v.locationID(join(path, "locationId"), order.LocationID)
+	v.diversityZone(join(path, "diversityZone"), order.DiversityZone)
+
+	if len(order.VendorConfig) == 0 {
+		v.add(join(path, "vendorConfig"), order.VendorConfig, nil, "is required")
+	}
+
+	for i, vnic := range order.NetworkInterfaces {
+		if vnic == nil {
+			v.add(index(join(path, "vnics"), i), vnic, nil, "must not be nil")
+			continue
+		}
+		v.vlan(join(index(join(path, "vnics"), i), "vlan"), vnic.VLAN)
+	}
+}
+
+func (v *validator) vxcOrder(path string, order types.VXCOrderConfiguration) {
+	v.name(join(path, "productName"), order.Name)
+	v.tags(join(path, "attributeTags"), order.AttributeTags)
+	v.rateLimit(join(path, "rateLimit"), order.RateLimit)
+	v.aEnd(join(path, "aEnd"), order.AEnd)
+
+	v.required(join(path, "bEnd.productUid"), order.BEnd.ProductUID)
+	v.vlan(join(path, "bEnd.vlan"), order.BEnd.VLAN)
+	if order.BEnd.VXCOrderMVEConfig != nil {
+		v.vlan(join(path, "bEnd.innerVlan"), order.BEnd.InnerVLAN)
+	}
+}
+
+func (v *validator) aEnd(path string, aEnd types.VXCOrderAEndConfiguration) {
+	v.vlan(join(path, "vlan"), aEnd.VLAN)
+	if aEnd.VXCOrderMVEConfig != nil {
+		v.vlan(join(path, "innerVlan"), aEnd.InnerVLAN)
+	}
+
+	for i, iface := range aEnd.PartnerConfig.Interfaces {
+		v.partnerConfigInterface(index(join(path, "partnerConfig.interfaces"), i), iface)
+	}
+}
+
+func (v *validator) partnerConfigInterface(path string, iface types.PartnerConfigInterface) {
+	for i, address := range iface.IpAddresses {
+		v.cidr(index(join(path, "ipAddresses"), i), address)
+	}
+	for i, address := range iface.NatIpAddresses {
+		v.ip(index(join(path, "natIpAddresses"), i), address)
+	}
+	for i, route := range iface.IpRoutes {
+		routePath := index(join(path, "ipRoutes"), i)
+		v.cidr(join(routePath, "prefix"), route.Prefix)
+		v.ip(join(routePath, "nextHop"), route.NextHop)
+	}
+
+	if iface.Bfd.TxInterval != 0 && (iface.Bfd.TxInterval < 300 || iface.Bfd.TxInterval > 9000) {
+		v.add(join(path, "bfd.txInterval"), iface.Bfd.TxInterval, nil, "must be between 300 and 9000 milliseconds")
+	}
+	if iface.Bfd.RxInterval != 0 && (iface.Bfd.RxInterval < 300 || iface.Bfd.RxInterval > 9000) {
+		v.add(join(path, "bfd.rxInterval"), iface.Bfd.RxInterval, nil, "must be between 300 and 9000 milliseconds")
+	}
+	if iface.Bfd.Multiplier != 0 && (iface.Bfd.Multiplier < 3 || iface.Bfd.Multiplier > 20) {
+		v.add(join(path, "bfd.multiplier"), iface.Bfd.Multiplier, nil, "must be between 3 and 20")
+	}
+
+	for i, bgp := range iface.BgpConnections {
+		bgpPath := index(join(path, "bgpConnections"), i)
+		v.asn(join(bgpPath, "peerAsn"), bgp.PeerAsn, true)
+		v.ip(join(bgpPath, "localIpAddress"), bgp.LocalIpAddress)
+		v.ip(join(bgpPath, "peerIpAddress"), bgp.PeerIpAddress)
+
+		if bgp.ExportPolicy != "" && bgp.ExportPolicy != "permit" && bgp.ExportPolicy != "deny" {
+			v.add(join(bgpPath, "exportPolicy"), bgp.ExportPolicy, nil,
+				"invalid export policy %q, valid policies are permit and deny", bgp.ExportPolicy)
+		}
+		if bgp.MedIn < 0 {
+			v.add(join(bgpPath, "medIn"), bgp.MedIn, nil, "must not be negative")
+		}
+		if bgp.MedOut < 0 {
+			v.add(join(bgpPath, "medOut"), bgp.MedOut, nil, "must not be negative")
+		}
+	}
+}
+
+func (v *validator) awsVXCOrder(path string, order types.AWSVXCOrderConfiguration) {
+	v.name(join(path, "productName"), order.Name)
+	v.tags(join(path, "attributeTags"), order.AttributeTags)
+	v.rateLimit(join(path, "rateLimit"), order.RateLimit)
+	v.aEnd(join(path, "aEnd"), order.AEnd)
+	v.required(join(path, "bEnd.productUid"), order.BEnd.ProductUID)
+	v.awsPartnerConfig(join(path, "bEnd.partnerConfig"), order.BEnd.PartnerConfig)
+}
+
+func (v *validator) awsPartnerConfig(path string, config types.AWSVXCOrderBEndPartnerConfig) {
+	if config.ConnectType != "AWS" && config.ConnectType != "AWSHC" {
+		v.add(join(path, "connectType"), config.ConnectType, nil,
+			"invalid connect type %q, valid types are AWS and AWSHC", config.ConnectType)
+	}
+	if config.Type != "" && config.Type != "private" && config.Type != "public" && config.Type != "transit" {
+		v.add(join(path, "type"), config.Type, nil, "invalid type %q, valid types are private, public and transit", config.Type)
+	}
+
+	if v.required(join(path, "ownerAccount"), config.OwnerAccount) && !isDigits(config.OwnerAccount, 12) {
+		v.add(join(path, "ownerAccount"), config.OwnerAccount, nil, "must be a 12 digit AWS account ID")
+	}
+
+	v.asn(join(path, "asn"), config.ASN, false)
+	v.asn(join(path, "amazonAsn"), config.AmazonASN, false)
+
+	if config.CustomerIPAddress != "" {
+		v.cidr(join(path, "customerIpAddress"), config.CustomerIPAddress)
+	}
+	if config.AmazonIPAddress != "" {
+		v.cidr(join(path, "amazonIpAddress"), config.AmazonIPAddress)
+	}
+	if config.Prefixes != "" {
+		v.cidrList(join(path, "prefixes"), config.Prefixes)
+	}
+}
+
+func (v *validator) partnerVXCOrder(path string, order types.PartnerOrderContents) {
+	v.name(join(path, "productName"), order.Name)
+	v.tags(join(path, "attributeTags"), order.AttributeTags)
+	v.rateLimit(join(path, "rateLimit"), order.RateLimit)
+	v.aEnd(join(path, "aEnd"), order.AEnd)
+	v.required(join(path, "bEnd.productUid"), order.BEnd.PartnerPortID)
+
+	configPath := join(path, "bEnd.partnerConfig")
+	switch config := order.BEnd.PartnerConfig.(type) {
+	case types.PartnerOrderAzurePartnerConfig:
+		v.azurePartnerConfig(configPath, config)
+	case types.PartnerOrderGooglePartnerConfig:
+		v.required(join(configPath, "pairingKey"), config.PairingKey)
+	case types.PartnerOrderOciPartnerConfig:
+		v.required(join(configPath, "virtualCircuitId"), config.VirtualCircutId)
+	case types.AWSVXCOrderBEndPartnerConfig:
+		v.awsPartnerConfig(configPath, config)
+	case nil:
+		v.add(configPath, nil, nil, "is required")
+	}
+}
+
+func (v *validator) azurePartnerConfig(path string, config types.PartnerOrderAzurePartnerConfig) {
+	v.required(join(path, "serviceKey"), config.ServiceKey)
+	for i, peering := range config.Peers {
+		v.azurePeering(index(join(path, "peers"), i), peering)
+	}
+}
+
+func (v *validator) azurePeering(path string, peering types.PartnerOrderAzurePeeringConfig) {
+	if peering.Type != "private" && peering.Type != "microsoft" {
+		v.add(join(path, "type"), peering.Type, nil, "invalid peering type %q, valid types are private and microsoft", peering.Type)
+	}
+	if peering.PeerASN != "" && !isDigits(peering.PeerASN, 0) {
+		v.add(join(path, "peer_asn"), peering.PeerASN, nil, "invalid ASN %q", peering.PeerASN)
+	}
+
+	v.cidr(join(path, "primary_subnet"), peering.PrimarySubnet)
+	v.cidr(join(path, "secondary_subnet"), peering.SecondarySubnet)
+	if peering.Prefixes != "" {
+		v.cidrList(join(path, "prefixes"), peering.Prefixes)
+	}
+
+	if peering.VLAN < MIN_VLAN || peering.VLAN > MAX_VLAN {
+		v.add(join(path, "vlan"), peering.VLAN, nil, "invalid VLAN %d, VLANs are between %d and %d", peering.VLAN, MIN_VLAN, MAX_VLAN)
+	}
+}
+
+// isDigits reports whether s is made up of digits only, and is length characters long if length is not 0.
+func isDigits(s string, length int) bool {
+	if s == "" || (length != 0 && len(s) != length) {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func join(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	if field == "" || strings.HasPrefix(field, "[") {
+		return path + field
+	}
+	return path + "." + field
+}
+
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
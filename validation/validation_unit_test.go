@@ -0,0 +1,220 @@
+//go:build unit
+// +build unit
+
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fields returns the paths of the fields at fault in err.
+func fields(t *testing.T, err error) []string {
+	validationErr, ok := mega_err.AsValidationError(err)
+	if !assert.True(t, ok, "expected a validation error, got %v", err) {
+		return nil
+	}
+
+	var paths []string
+	for _, fieldErr := range validationErr.Errors {
+		paths = append(paths, fieldErr.Field)
+	}
+	return paths
+}
+
+func TestValidatePortOrder(t *testing.T) {
+	assert.NoError(t, ValidatePortOrder(types.PortOrder{Name: "Port", Term: 12, PortSpeed: 10000, LocationID: 1}))
+	// The speeds a location offers are left to the API.
+	assert.NoError(t, ValidatePortOrder(types.PortOrder{Name: "Port", Term: 12, PortSpeed: 400000, LocationID: 1}))
+
+	err := ValidatePortOrder(types.PortOrder{
+		Name:          strings.Repeat("x", MAX_NAME_LENGTH+1),
+		Term:          6,
+		PortSpeed:     40000,
+		LagPortCount:  9,
+		DiversityZone: "green",
+	})
+	assert.ErrorIs(t, err, mega_err.ErrInvalidOrder)
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+	assert.Equal(t, []string{"productName", "term", "locationId", "diversityZone", "lagPortCount"}, fields(t, err))
+}
+
+func TestValidateMCROrder(t *testing.T) {
+	err := ValidateMCROrder(types.MCROrder{
+		Name:       "MCR",
+		Term:       1,
+		PortSpeed:  3000,
+		LocationID: 1,
+		Config: types.MCROrderConfig{
+			ASN: -1,
+			PrefixFilterLists: []types.MCRPrefixFilterList{{
+				Description:   "List",
+				AddressFamily: "IPv4",
+				Entries: []types.MCRPrefixListEntry{
+					{Action: "permit", Prefix: "10.0.0.0/8", Ge: 16, Le: 24},
+					{Action: "allow", Prefix: "2001:db8::/32"},
+					{Action: "deny", Prefix: "192.168.0.0/16", Ge: 8},
+					{Action: "deny", Prefix: "not-a-prefix"},
+				},
+			}},
+		},
+	})
+	assert.ErrorIs(t, err, mega_err.ErrMCRInvalidPortSpeed)
+	assert.Equal(t, []string{
+		"config.mcrAsn",
+		"portSpeed",
+		"config.prefixFilterLists[0].entries[1].action",
+		"config.prefixFilterLists[0].entries[1].prefix",
+		"config.prefixFilterLists[0].entries[2].ge",
+		"config.prefixFilterLists[0].entries[3].prefix",
+	}, fields(t, err))
+}
+
+func TestValidateMVEOrder(t *testing.T) {
+	err := ValidateMVEOrder(types.MVEOrderConfig{
+		Name:              "MVE",
+		Term:              12,
+		LocationID:        1,
+		NetworkInterfaces: []*types.MVENetworkInterface{{Description: "Data", VLAN: 5000}, nil},
+	})
+	assert.Equal(t, []string{"vendorConfig", "vnics[0].vlan", "vnics[1]"}, fields(t, err))
+}
+
+func TestValidateVXCOrder(t *testing.T) {
+	valid := types.VXCOrderConfiguration{
+		Name:      "VXC",
+		RateLimit: 100,
+		AEnd: types.VXCOrderAEndConfiguration{
+			VLAN: UNTAGGED_VLAN,
+			PartnerConfig: types.VXCOrderAEndPartnerConfig{Interfaces: []types.PartnerConfigInterface{{
+				IpAddresses:    []string{"10.192.0.25/29"},
+				NatIpAddresses: []string{"10.192.0.25"},
+				IpRoutes:       []types.IpRoute{{Prefix: "10.0.0.0/16", NextHop: "10.192.0.26"}},
+				Bfd:            types.BfdConfig{TxInterval: 300, RxInterval: 300, Multiplier: 3},
+				BgpConnections: []types.BgpConnectionConfig{{PeerAsn: 64512, LocalIpAddress: "10.192.0.25", PeerIpAddress: "10.192.0.26"}},
+			}}},
+		},
+		BEnd: types.VXCOrderBEndConfiguration{ProductUID: "port-uid", VLAN: 100},
+	}
+	assert.NoError(t, ValidateVXCOrder(valid))
+
+	invalid := valid
+	invalid.RateLimit = 0
+	invalid.BEnd = types.VXCOrderBEndConfiguration{VLAN: 1}
+	invalid.AEnd.PartnerConfig.Interfaces = []types.PartnerConfigInterface{{
+		IpAddresses:    []string{"10.192.0.25"},
+		Bfd:            types.BfdConfig{TxInterval: 50},
+		BgpConnections: []types.BgpConnectionConfig{{LocalIpAddress: "10.192.0.256", PeerIpAddress: "10.192.0.26"}},
+	}}
+
+	err := ValidateVXCOrder(invalid)
+	assert.Equal(t, []string{
+		"rateLimit",
+		"aEnd.partnerConfig.interfaces[0].ipAddresses[0]",
+		"aEnd.partnerConfig.interfaces[0].bfd.txInterval",
+		"aEnd.partnerConfig.interfaces[0].bgpConnections[0].peerAsn",
+		"aEnd.partnerConfig.interfaces[0].bgpConnections[0].localIpAddress",
+		"bEnd.productUid",
+		"bEnd.vlan",
+	}, fields(t, err))
+}
+
+func TestValidateAWSPartnerConfig(t *testing.T) {
+	assert.NoError(t, ValidateAWSPartnerConfig(types.AWSVXCOrderBEndPartnerConfig{
+		ConnectType: "AWS", Type: "private", OwnerAccount: "684021030471", ASN: 65000, Prefixes: "10.0.0.0/8, 172.16.0.0/12",
+	}))
+
+	err := ValidateAWSPartnerConfig(types.AWSVXCOrderBEndPartnerConfig{
+		ConnectType:       "AZURE",
+		Type:              "hosted",
+		OwnerAccount:      "68402103047",
+		CustomerIPAddress: "10.0.0.1",
+		Prefixes:          "10.0.0.0/8,bogus",
+	})
+	assert.Equal(t, []string{"connectType", "type", "ownerAccount", "customerIpAddress", "prefixes"}, fields(t, err))
+}
+
+func TestValidatePartnerVXCOrder(t *testing.T) {
+	err := ValidatePartnerVXCOrder(types.PartnerOrderContents{
+		Name:      "ExpressRoute",
+		RateLimit: 100,
+		BEnd: types.PartnerOrderBEndConfiguration{
+			PartnerPortID: "azure-port",
+			PartnerConfig: types.PartnerOrderAzurePartnerConfig{
+				ConnectType: "AZURE",
+				Peers: []types.PartnerOrderAzurePeeringConfig{
+					{Type: "private", PeerASN: "64555", PrimarySubnet: "10.0.0.0/30", SecondarySubnet: "10.0.0.4/30", VLAN: 100},
+					{Type: "public", PeerASN: "AS1", PrimarySubnet: "10.0.0.8", SecondarySubnet: "10.0.0.12/30"},
+				},
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"bEnd.partnerConfig.serviceKey",
+		"bEnd.partnerConfig.peers[1].type",
+		"bEnd.partnerConfig.peers[1].peer_asn",
+		"bEnd.partnerConfig.peers[1].primary_subnet",
+		"bEnd.partnerConfig.peers[1].vlan",
+	}, fields(t, err))
+
+	err = ValidatePartnerVXCOrder(types.PartnerOrderContents{
+		Name:      "Interconnect",
+		RateLimit: 100,
+		BEnd: types.PartnerOrderBEndConfiguration{
+			PartnerPortID: "google-port",
+			PartnerConfig: types.PartnerOrderGooglePartnerConfig{ConnectType: "GOOGLE"},
+		},
+	})
+	assert.Equal(t, []string{"bEnd.partnerConfig.pairingKey"}, fields(t, err))
+}
+
+func TestValidateUpdates(t *testing.T) {
+	assert.NoError(t, Validate(types.ProductUpdate{Name: "Port"}))
+	assert.Equal(t, []string{"name"}, fields(t, Validate(types.ProductUpdate{})))
+
+	bEndVLAN := 4094
+	err := Validate(types.VXCUpdate{Name: "VXC", RateLimit: 100, AEndVLAN: 100, BEndVLAN: &bEndVLAN})
+	assert.Equal(t, []string{"bEndVlan"}, fields(t, err))
+}
+
+func TestValidateTags(t *testing.T) {
+	assert.NoError(t, ValidateTags(types.Tags{"owner": "networks"}))
+	assert.Equal(t, []string{"resourceTags"}, fields(t, ValidateTags(types.Tags{"": "networks"})))
+
+	err := ValidateVXCOrder(types.VXCOrderConfiguration{Name: "VXC", RateLimit: 100,
+		BEnd: types.VXCOrderBEndConfiguration{ProductUID: "b-end"}, AttributeTags: types.Tags{" ": "blank"}})
+	assert.Equal(t, []string{"attributeTags"}, fields(t, err))
+}
+
+func TestErrors(t *testing.T) {
+	var errs Errors
+	assert.NoError(t, errs.Err())
+
+	errs.Add("[0]", ValidatePortOrder(types.PortOrder{Name: "Port", Term: 5, PortSpeed: 10000, LocationID: 1}))
+	errs.Add("[1]", nil)
+	errs.Add("[2]", errors.New("not an order"))
+
+	err := errs.Err()
+	assert.Equal(t, []string{"[0].term", "[2]"}, fields(t, err))
+	assert.ErrorIs(t, err, mega_err.ErrTermNotValid)
+	assert.Contains(t, err.Error(), "2 problems")
+}